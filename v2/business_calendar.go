@@ -0,0 +1,272 @@
+package moviebuff
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Layout of the dates of holidays.
+const holidayDateLayout = "2006-01-02"
+
+// defaultWeekend are the weekend days of countries not listed in weekendDays.
+var defaultWeekend = []time.Weekday{time.Saturday, time.Sunday}
+
+// weekendDays maps ISO 2-digit country codes to the weekend days of countries
+// whose weekend is not Saturday and Sunday.
+var weekendDays = map[string][]time.Weekday{
+	"AF": {time.Thursday, time.Friday},
+	"BD": {time.Friday, time.Saturday},
+	"BH": {time.Friday, time.Saturday},
+	"DZ": {time.Friday, time.Saturday},
+	"EG": {time.Friday, time.Saturday},
+	"IL": {time.Friday, time.Saturday},
+	"IQ": {time.Friday, time.Saturday},
+	"IR": {time.Friday},
+	"JO": {time.Friday, time.Saturday},
+	"KW": {time.Friday, time.Saturday},
+	"NP": {time.Saturday},
+	"OM": {time.Friday, time.Saturday},
+	"QA": {time.Friday, time.Saturday},
+	"SA": {time.Friday, time.Saturday},
+}
+
+// DefaultWeekend returns Saturday and Sunday, the weekend days of most countries.
+func DefaultWeekend() []time.Weekday {
+	return append([]time.Weekday(nil), defaultWeekend...)
+}
+
+// WeekendFor returns the weekend days of the country with the given ISO 2-digit code,
+// or DefaultWeekend if the country's weekend is Saturday and Sunday or unknown.
+// Pass other weekend days to NewBusinessCalendar to use a different weekend.
+func WeekendFor(country string) []time.Weekday {
+	if weekend, ok := weekendDays[strings.ToUpper(country)]; ok {
+		return append([]time.Weekday(nil), weekend...)
+	}
+	return DefaultWeekend()
+}
+
+// GetDate returns the date of the holiday at midnight in loc.
+func (h Holiday) GetDate(loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation(holidayDateLayout, h.Date, loc)
+}
+
+// BusinessCalendar answers working day questions using the holidays of a Calendar.
+// All dates are evaluated in the time zone of the calendar, ignoring the time of day.
+type BusinessCalendar struct {
+	Calendar *Calendar
+
+	// Location of the calendar's time zone.
+	Location *time.Location
+
+	weekend  map[time.Weekday]bool
+	holidays map[string][]Holiday
+}
+
+// LongWeekend is a run of at least three consecutive days off including at least one holiday.
+type LongWeekend struct {
+	// First and last day off, at midnight in the calendar's time zone.
+	Start time.Time
+	End   time.Time
+
+	// Days is the number of days off.
+	Days int
+
+	// Holidays falling in the long weekend.
+	Holidays []Holiday
+}
+
+// NewBusinessCalendar returns a business calendar for c with the given weekend days.
+// If no weekend days are given DefaultWeekend is used, use WeekendFor to get the weekend of a country.
+// Holidays with malformed dates are ignored. An error is returned if the time zone of c is unknown.
+func NewBusinessCalendar(c *Calendar, weekend ...time.Weekday) (*BusinessCalendar, error) {
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(weekend) == 0 {
+		weekend = defaultWeekend
+	}
+
+	b := &BusinessCalendar{
+		Calendar: c,
+		Location: loc,
+		weekend:  map[time.Weekday]bool{},
+		holidays: map[string][]Holiday{},
+	}
+	for _, d := range weekend {
+		b.weekend[d] = true
+	}
+	for _, h := range c.Holidays {
+		if _, err := h.GetDate(loc); err != nil {
+			continue
+		}
+		b.holidays[h.Date] = append(b.holidays[h.Date], h)
+	}
+	return b, nil
+}
+
+// day returns the midnight of the day t falls on in the calendar's time zone.
+func (b *BusinessCalendar) day(t time.Time) time.Time {
+	t = t.In(b.Location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, b.Location)
+}
+
+// addDays returns the midnight days after the day t.
+func (b *BusinessCalendar) addDays(t time.Time, days int) time.Time {
+	t = b.day(t)
+	return time.Date(t.Year(), t.Month(), t.Day()+days, 0, 0, 0, 0, b.Location)
+}
+
+// HolidaysOn returns the holidays falling on the day of t.
+func (b *BusinessCalendar) HolidaysOn(t time.Time) []Holiday {
+	return b.holidays[b.day(t).Format(holidayDateLayout)]
+}
+
+// IsHoliday reports whether the day of t is a holiday.
+func (b *BusinessCalendar) IsHoliday(t time.Time) bool {
+	return len(b.HolidaysOn(t)) > 0
+}
+
+// IsWeekend reports whether the day of t is a weekend day.
+func (b *BusinessCalendar) IsWeekend(t time.Time) bool {
+	return b.weekend[b.day(t).Weekday()]
+}
+
+// IsWorkingDay reports whether the day of t is neither a weekend day nor a holiday.
+func (b *BusinessCalendar) IsWorkingDay(t time.Time) bool {
+	return !b.IsWeekend(t) && !b.IsHoliday(t)
+}
+
+// HolidaysBetween returns the holidays from the day of from to the day of to, both inclusive, sorted by date.
+func (b *BusinessCalendar) HolidaysBetween(from, to time.Time) []Holiday {
+	first := b.day(from).Format(holidayDateLayout)
+	last := b.day(to).Format(holidayDateLayout)
+
+	var holidays []Holiday
+	for date, hs := range b.holidays {
+		if date >= first && date <= last {
+			holidays = append(holidays, hs...)
+		}
+	}
+
+	sort.SliceStable(holidays, func(i, j int) bool {
+		return holidays[i].Date < holidays[j].Date
+	})
+	return holidays
+}
+
+// NextWorkingDay returns the first working day after the day of t.
+// The zero time is returned if there is no working day, which only happens if every day is a weekend day.
+func (b *BusinessCalendar) NextWorkingDay(t time.Time) time.Time {
+	return b.seekWorkingDay(t, 1)
+}
+
+// PreviousWorkingDay returns the last working day before the day of t.
+// The zero time is returned if there is no working day, which only happens if every day is a weekend day.
+func (b *BusinessCalendar) PreviousWorkingDay(t time.Time) time.Time {
+	return b.seekWorkingDay(t, -1)
+}
+
+func (b *BusinessCalendar) seekWorkingDay(t time.Time, step int) time.Time {
+	if len(b.weekend) >= 7 {
+		return time.Time{}
+	}
+	for d := b.addDays(t, step); ; d = b.addDays(d, step) {
+		if b.IsWorkingDay(d) {
+			return d
+		}
+	}
+}
+
+// WorkingDaysBetween returns the number of working days from the day of from, inclusive,
+// to the day of to, exclusive. The result is negative if to is before from.
+func (b *BusinessCalendar) WorkingDaysBetween(from, to time.Time) int {
+	sign := 1
+	start, end := b.day(from), b.day(to)
+	if end.Before(start) {
+		sign = -1
+		start, end = end, start
+	}
+
+	count := 0
+	for d := start; d.Before(end); d = b.addDays(d, 1) {
+		if b.IsWorkingDay(d) {
+			count++
+		}
+	}
+	return sign * count
+}
+
+// AddWorkingDays returns the day n working days after the day of t, or before it if n is negative.
+// The day of t is returned if n is zero.
+func (b *BusinessCalendar) AddWorkingDays(t time.Time, n int) time.Time {
+	d := b.day(t)
+	for ; n > 0; n-- {
+		d = b.NextWorkingDay(d)
+	}
+	for ; n < 0; n++ {
+		d = b.PreviousWorkingDay(d)
+	}
+	return d
+}
+
+// LongWeekends returns the long weekends of the calendar, sorted by date.
+func (b *BusinessCalendar) LongWeekends() []LongWeekend {
+	dates := make([]string, 0, len(b.holidays))
+	for date := range b.holidays {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	var weekends []LongWeekend
+	for _, date := range dates {
+		holiday, _ := time.ParseInLocation(holidayDateLayout, date, b.Location)
+		if len(weekends) > 0 && !holiday.After(weekends[len(weekends)-1].End) {
+			continue
+		}
+
+		start, end := holiday, holiday
+		for !b.IsWorkingDay(b.addDays(start, -1)) && len(b.weekend) < 7 {
+			start = b.addDays(start, -1)
+		}
+		for !b.IsWorkingDay(b.addDays(end, 1)) && len(b.weekend) < 7 {
+			end = b.addDays(end, 1)
+		}
+
+		days := 0
+		for d := start; !d.After(end); d = b.addDays(d, 1) {
+			days++
+		}
+		if days < 3 {
+			continue
+		}
+
+		weekends = append(weekends, LongWeekend{
+			Start:    start,
+			End:      end,
+			Days:     days,
+			Holidays: b.HolidaysBetween(start, end),
+		})
+	}
+	return weekends
+}
+
+// LongWeekendsBetween returns the long weekends overlapping the days from from to to, both inclusive.
+func (b *BusinessCalendar) LongWeekendsBetween(from, to time.Time) []LongWeekend {
+	first, last := b.day(from), b.day(to)
+
+	var weekends []LongWeekend
+	for _, w := range b.LongWeekends() {
+		if !w.End.Before(first) && !w.Start.After(last) {
+			weekends = append(weekends, w)
+		}
+	}
+	return weekends
+}
+
+// IsLongWeekend reports whether the day of t falls in a long weekend.
+func (b *BusinessCalendar) IsLongWeekend(t time.Time) bool {
+	return len(b.LongWeekendsBetween(t, t)) > 0
+}
@@ -0,0 +1,85 @@
+package moviebuff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testBusinessCalendar(t *testing.T, weekend ...time.Weekday) *BusinessCalendar {
+	b, err := NewBusinessCalendar(&Calendar{
+		Name:     "Holidays in India",
+		TimeZone: "Asia/Kolkata",
+		Holidays: []Holiday{
+			{ID: "1", Name: "Republic Day", Date: "2024-01-26"},
+			{ID: "2", Name: "Holi", Date: "2024-03-25"},
+			{ID: "3", Name: "Good Friday", Date: "2024-03-29"},
+			{ID: "4", Name: "Independence Day", Date: "2024-08-15"},
+			{ID: "5", Name: "Broken", Date: "someday"},
+		},
+	}, weekend...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestBusinessCalendar(t *testing.T) {
+	assert := assert.New(t)
+	b := testBusinessCalendar(t)
+	ist := b.Location
+
+	// 2024-01-25 20:00 UTC is already Republic Day in India.
+	assert.True(b.IsHoliday(time.Date(2024, 1, 25, 20, 0, 0, 0, time.UTC)))
+	assert.False(b.IsHoliday(time.Date(2024, 1, 25, 12, 0, 0, 0, time.UTC)))
+	assert.Equal("Republic Day", b.HolidaysOn(time.Date(2024, 1, 26, 0, 0, 0, 0, ist))[0].Name)
+
+	assert.True(b.IsWeekend(time.Date(2024, 1, 27, 10, 0, 0, 0, ist)))
+	assert.False(b.IsWorkingDay(time.Date(2024, 1, 26, 10, 0, 0, 0, ist)))
+	assert.True(b.IsWorkingDay(time.Date(2024, 1, 25, 10, 0, 0, 0, ist)))
+
+	holidays := b.HolidaysBetween(time.Date(2024, 3, 1, 0, 0, 0, 0, ist), time.Date(2024, 8, 15, 0, 0, 0, 0, ist))
+	var names []string
+	for _, h := range holidays {
+		names = append(names, h.Name)
+	}
+	assert.Equal([]string{"Holi", "Good Friday", "Independence Day"}, names)
+
+	assert.Equal(time.Date(2024, 1, 29, 0, 0, 0, 0, ist), b.NextWorkingDay(time.Date(2024, 1, 25, 18, 0, 0, 0, ist)))
+	assert.Equal(time.Date(2024, 3, 22, 0, 0, 0, 0, ist), b.PreviousWorkingDay(time.Date(2024, 3, 26, 0, 0, 0, 0, ist)))
+	assert.Equal(time.Date(2024, 1, 30, 0, 0, 0, 0, ist), b.AddWorkingDays(time.Date(2024, 1, 25, 0, 0, 0, 0, ist), 2))
+	assert.Equal(time.Date(2024, 1, 25, 0, 0, 0, 0, ist), b.AddWorkingDays(time.Date(2024, 1, 30, 0, 0, 0, 0, ist), -2))
+
+	// Mon 2024-03-25 to Mon 2024-04-01: Holi and Good Friday are off.
+	assert.Equal(3, b.WorkingDaysBetween(time.Date(2024, 3, 25, 0, 0, 0, 0, ist), time.Date(2024, 4, 1, 0, 0, 0, 0, ist)))
+	assert.Equal(-3, b.WorkingDaysBetween(time.Date(2024, 4, 1, 0, 0, 0, 0, ist), time.Date(2024, 3, 25, 0, 0, 0, 0, ist)))
+
+	weekends := b.LongWeekends()
+	assert.Len(weekends, 3)
+	assert.Equal(time.Date(2024, 1, 26, 0, 0, 0, 0, ist), weekends[0].Start)
+	assert.Equal(time.Date(2024, 1, 28, 0, 0, 0, 0, ist), weekends[0].End)
+	assert.Equal(3, weekends[0].Days)
+	assert.Equal(time.Date(2024, 3, 23, 0, 0, 0, 0, ist), weekends[1].Start)
+	assert.Equal("Holi", weekends[1].Holidays[0].Name)
+	assert.Equal("Good Friday", weekends[2].Holidays[0].Name)
+
+	assert.True(b.IsLongWeekend(time.Date(2024, 3, 31, 12, 0, 0, 0, ist)))
+	assert.False(b.IsLongWeekend(time.Date(2024, 8, 15, 12, 0, 0, 0, ist)))
+	assert.Len(b.LongWeekendsBetween(time.Date(2024, 3, 1, 0, 0, 0, 0, ist), time.Date(2024, 3, 24, 0, 0, 0, 0, ist)), 1)
+}
+
+func TestBusinessCalendar_Weekend(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]time.Weekday{time.Friday, time.Saturday}, WeekendFor("sa"))
+	assert.Equal(DefaultWeekend(), WeekendFor("IN"))
+
+	// With a Friday and Saturday weekend, Independence Day on Thursday makes a long weekend.
+	b := testBusinessCalendar(t, WeekendFor("SA")...)
+	assert.True(b.IsLongWeekend(time.Date(2024, 8, 15, 12, 0, 0, 0, b.Location)))
+	assert.True(b.IsWorkingDay(time.Date(2024, 1, 28, 12, 0, 0, 0, b.Location)))
+
+	_, err := NewBusinessCalendar(&Calendar{TimeZone: "Nowhere/Unknown"})
+	assert.Error(err)
+}
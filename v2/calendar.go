@@ -14,4 +14,7 @@ type Holiday struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 	Date string `json:"date"`
+
+	// Status of the holiday as returned by the API, like "confirmed".
+	Status string `json:"status,omitempty"`
 }
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+	"github.com/RealImage/moviebuff-sdk-go/v2/cpl"
+)
+
+// Default page of the list command
+const (
+	DEFAULT_LIST_LIMIT = 20
+	DEFAULT_LIST_PAGE  = 1
+)
+
+// DEFAULT_CPL_CONCURRENCY is the number of CPLs of a directory resolved at once unless -concurrency is given.
+const DEFAULT_CPL_CONCURRENCY = 4
+
+// cplIDPattern matches the IDs of mapped CPLs, a UUID or a number.
+var cplIDPattern = regexp.MustCompile(`^([0-9]+|[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12})$`)
+
+// commands of the tool, mapped against their name.
+var commands = map[string]command{
+	"movie": {
+		args:  "<id>",
+		usage: "Prints a movie, by UUID or URL like 12-years-a-slave.",
+		run: func(ctx context.Context, mb moviebuff.Moviebuff, fs *flag.FlagSet) (interface{}, error) {
+			id, err := oneArg(fs)
+			if err != nil {
+				return nil, err
+			}
+			return mb.GetMovie(ctx, id)
+		},
+	},
+	"person": {
+		args:  "<id>",
+		usage: "Prints a person, by UUID or URL.",
+		run: func(ctx context.Context, mb moviebuff.Moviebuff, fs *flag.FlagSet) (interface{}, error) {
+			id, err := oneArg(fs)
+			if err != nil {
+				return nil, err
+			}
+			return mb.GetPerson(ctx, id)
+		},
+	},
+	"entity": {
+		args:  "<id>",
+		usage: "Prints an entity, by UUID or URL.",
+		run: func(ctx context.Context, mb moviebuff.Moviebuff, fs *flag.FlagSet) (interface{}, error) {
+			id, err := oneArg(fs)
+			if err != nil {
+				return nil, err
+			}
+			return mb.GetEntity(ctx, id)
+		},
+	},
+	"list": {
+		args:  "<movies|people|entities>",
+		usage: "Prints a page of movies, people or entities.",
+		flags: func(fs *flag.FlagSet) {
+			fs.Int("limit", DEFAULT_LIST_LIMIT, "number of resources per page")
+			fs.Int("page", DEFAULT_LIST_PAGE, "page to print, starting at 1")
+		},
+		run: func(ctx context.Context, mb moviebuff.Moviebuff, fs *flag.FlagSet) (interface{}, error) {
+			arg, err := oneArg(fs)
+			if err != nil {
+				return nil, err
+			}
+			resourceType := moviebuff.ResourceType(arg)
+			switch resourceType {
+			case moviebuff.RESOURCE_TYPE_MOVIES, moviebuff.RESOURCE_TYPE_PEOPLE, moviebuff.RESOURCE_TYPE_ENTITIES:
+			default:
+				return nil, fmt.Errorf("unknown resource type %q, expected movies, people or entities", arg)
+			}
+			return mb.GetResources(ctx, resourceType, intFlag(fs, "limit"), intFlag(fs, "page"))
+		},
+	},
+	"certifications": {
+		args:  "[country]",
+		usage: "Prints the certifications, of a country if given by UUID or ISO code like IN.",
+		run: func(ctx context.Context, mb moviebuff.Moviebuff, fs *flag.FlagSet) (interface{}, error) {
+			if fs.NArg() > 1 {
+				return nil, errUsage
+			}
+			return mb.GetCertifications(ctx, fs.Arg(0))
+		},
+	},
+	"holidays": {
+		args:  "<country>",
+		usage: "Prints the holiday calendar of a country.",
+		run: func(ctx context.Context, mb moviebuff.Moviebuff, fs *flag.FlagSet) (interface{}, error) {
+			country, err := oneArg(fs)
+			if err != nil {
+				return nil, err
+			}
+			return mb.GetHolidayCalendar(ctx, country)
+		},
+	},
+	"languages": {
+		args:  "",
+		usage: "Prints the languages known to Moviebuff.",
+		run: func(ctx context.Context, mb moviebuff.Moviebuff, fs *flag.FlagSet) (interface{}, error) {
+			if fs.NArg() > 0 {
+				return nil, errUsage
+			}
+			return mb.GetLanguages(ctx)
+		},
+	},
+	"cpl": {
+		args:  "<id|file|dir>",
+		usage: "Prints the movie a CPL is mapped to, by CPL ID or CPL file, or the report of every CPL found in a directory.",
+		flags: func(fs *flag.FlagSet) {
+			fs.Int("concurrency", DEFAULT_CPL_CONCURRENCY, "number of CPLs of a directory resolved at once")
+		},
+		run: func(ctx context.Context, mb moviebuff.Moviebuff, fs *flag.FlagSet) (interface{}, error) {
+			arg, err := oneArg(fs)
+			if err != nil {
+				return nil, err
+			}
+
+			info, err := os.Stat(arg)
+			switch {
+			case os.IsNotExist(err) && cplIDPattern.MatchString(arg):
+				return mb.GetMappedCPL(ctx, arg)
+			case err != nil:
+				return nil, err
+			case info.IsDir():
+				return cpl.Scan(ctx, mb, arg, cpl.ScanOptions{Concurrency: intFlag(fs, "concurrency")})
+			}
+
+			c, err := cpl.ParseFile(arg)
+			if err != nil {
+				return nil, err
+			}
+			return cpl.Resolve(ctx, mb, []cpl.File{{Path: arg, CPL: c}}, cpl.ScanOptions{}), nil
+		},
+	},
+}
+
+// oneArg returns the only argument of the command.
+func oneArg(fs *flag.FlagSet) (string, error) {
+	if fs.NArg() != 1 || fs.Arg(0) == "" {
+		return "", errUsage
+	}
+	return fs.Arg(0), nil
+}
+
+// intFlag returns the value of an int flag registered by the command.
+func intFlag(fs *flag.FlagSet, name string) int {
+	return fs.Lookup(name).Value.(flag.Getter).Get().(int)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Environment variables read by the tool
+const (
+	ENV_TOKEN    = "MOVIEBUFF_TOKEN"
+	ENV_HOST_URL = "MOVIEBUFF_HOST_URL"
+	ENV_CONFIG   = "MOVIEBUFF_CONFIG"
+)
+
+// config is how the tool connects to the API.
+type config struct {
+	Token   string `json:"token"`
+	HostURL string `json:"hostUrl"`
+}
+
+// loadConfig reads the configuration file at path and overrides it with the environment.
+// The file is looked up in MOVIEBUFF_CONFIG, then in the user's configuration directory if path is empty,
+// and may be missing unless path or MOVIEBUFF_CONFIG name it.
+func loadConfig(path string, getenv func(string) string) (*config, error) {
+	c := new(config)
+
+	required := true
+	if path == "" {
+		path = getenv(ENV_CONFIG)
+	}
+	if path == "" {
+		required = false
+		path = defaultConfigPath()
+	}
+
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := json.Unmarshal(data, c); err != nil {
+				return nil, fmt.Errorf("reading configuration file %s: %w", path, err)
+			}
+		case os.IsNotExist(err) && !required:
+		default:
+			return nil, err
+		}
+	}
+
+	if token := getenv(ENV_TOKEN); token != "" {
+		c.Token = token
+	}
+	if hostURL := getenv(ENV_HOST_URL); hostURL != "" {
+		c.HostURL = hostURL
+	}
+	return c, nil
+}
+
+// defaultConfigPath returns moviebuff/config.json in the user's configuration directory,
+// or an empty string if there is none.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "moviebuff", "config.json")
+}
+
+// validate checks that the API can be reached with c.
+func (c *config) validate() error {
+	if c.Token == "" {
+		return errors.New("no API key: use -token, " + ENV_TOKEN + " or the configuration file")
+	}
+	if c.HostURL == "" {
+		return errors.New("no API URL: use -host, " + ENV_HOST_URL + " or the configuration file")
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+// Command moviebuff queries the Moviebuff API from the command line.
+//
+// Usage:
+//
+//	moviebuff <command> [flags] [arguments]
+//
+// The commands are:
+//
+//	movie <id>              details of a movie, by UUID or URL like 12-years-a-slave
+//	person <id>             details of a person
+//	entity <id>             details of an entity
+//	list <type>             a page of movies, people or entities
+//	certifications [code]   certifications, of a country if given
+//	holidays <country>      holiday calendar of a country
+//	languages               languages known to Moviebuff
+//	cpl <id|file|dir>       movie a CPL is mapped to, by CPL ID, CPL file, or every CPL found in a directory
+//
+// Every command accepts the flags:
+//
+//	-format string    json, table or a Go template applied to the result (default "json")
+//	-token string     API key
+//	-host string      URL of the API
+//	-config string    path of the configuration file
+//	-timeout duration time allowed for the command (default 30s)
+//
+// The API key and URL are taken from the flags, then from the MOVIEBUFF_TOKEN and MOVIEBUFF_HOST_URL
+// environment variables, then from the configuration file. The configuration file is a JSON object
+// with "token" and "hostUrl" fields, read from MOVIEBUFF_CONFIG or moviebuff/config.json
+// in the user's configuration directory.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+)
+
+// Exit codes
+const (
+	EXIT_OK    = 0
+	EXIT_ERROR = 1
+	EXIT_USAGE = 2
+)
+
+// DEFAULT_TIMEOUT is the time allowed for a command unless -timeout is given.
+const DEFAULT_TIMEOUT = 30 * time.Second
+
+// errUsage is returned by commands called with wrong arguments.
+var errUsage = errors.New("usage")
+
+// command is a subcommand of the tool.
+type command struct {
+	args  string
+	usage string
+
+	// flags registers the flags of the command, in addition to the common ones.
+	flags func(fs *flag.FlagSet)
+
+	// run returns the result to print. The arguments and the flags of the command are read from fs.
+	run func(ctx context.Context, mb moviebuff.Moviebuff, fs *flag.FlagSet) (interface{}, error)
+}
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Getenv, os.Stdout, os.Stderr))
+}
+
+// run runs the command line args and returns the exit code.
+func run(ctx context.Context, args []string, getenv func(string) string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(stderr)
+		if len(args) == 0 {
+			return EXIT_USAGE
+		}
+		return EXIT_OK
+	}
+
+	name := args[0]
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "moviebuff: unknown command %q\n", name)
+		printUsage(stderr)
+		return EXIT_USAGE
+	}
+
+	fs := flag.NewFlagSet("moviebuff "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: moviebuff %s [flags] %s\n\n%s\n\n", name, cmd.args, cmd.usage)
+		fs.PrintDefaults()
+	}
+	format := fs.String("format", FORMAT_JSON, "json, table or a Go template applied to the result")
+	token := fs.String("token", "", "API key")
+	host := fs.String("host", "", "URL of the API")
+	configPath := fs.String("config", "", "path of the configuration file")
+	timeout := fs.Duration("timeout", DEFAULT_TIMEOUT, "time allowed for the command")
+	if cmd.flags != nil {
+		cmd.flags(fs)
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			return EXIT_OK
+		}
+		return EXIT_USAGE
+	}
+
+	out, err := newOutput(*format)
+	if err != nil {
+		fmt.Fprintf(stderr, "moviebuff: %v\n", err)
+		return EXIT_USAGE
+	}
+
+	config, err := loadConfig(*configPath, getenv)
+	if err != nil {
+		fmt.Fprintf(stderr, "moviebuff: %v\n", err)
+		return EXIT_ERROR
+	}
+	if *token != "" {
+		config.Token = *token
+	}
+	if *host != "" {
+		config.HostURL = *host
+	}
+	if err := config.validate(); err != nil {
+		fmt.Fprintf(stderr, "moviebuff: %v\n", err)
+		return EXIT_USAGE
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	mb := moviebuff.New(moviebuff.Config{
+		HostURL:     strings.TrimRight(config.HostURL, "/"),
+		StaticToken: config.Token,
+	})
+	result, err := cmd.run(ctx, mb, fs)
+	if err == errUsage {
+		fs.Usage()
+		return EXIT_USAGE
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "moviebuff: %v\n", err)
+		return EXIT_ERROR
+	}
+
+	if err := out.write(stdout, result); err != nil {
+		fmt.Fprintf(stderr, "moviebuff: %v\n", err)
+		return EXIT_ERROR
+	}
+	return EXIT_OK
+}
+
+// printUsage prints the commands of the tool.
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: moviebuff <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-16s %s\n", name, commands[name].usage)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "moviebuff <command> -h" for the flags of a command.`)
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+	"github.com/RealImage/moviebuff-sdk-go/v2/moviebufftest"
+	"github.com/stretchr/testify/assert"
+)
+
+const testCPL = `<?xml version="1.0" encoding="UTF-8"?>
+<CompositionPlaylist xmlns="http://www.smpte-ra.org/schemas/429-7/2006/CPL">
+  <Id>urn:uuid:AB9754D6-B1CF-4185-8554-4FC505670D7F</Id>
+  <ContentTitleText>AndhraKingThal_P2_FTR-2D-V3_S_TE-XX_IN-UA_51-Atmos_4K_ASV_20251125_ASV_SMPTE_VF</ContentTitleText>
+  <ContentKind>feature</ContentKind>
+  <ReelList>
+    <Reel>
+      <Id>urn:uuid:00000000-0000-0000-0000-000000000001</Id>
+      <AssetList>
+        <MainPicture>
+          <Id>urn:uuid:00000000-0000-0000-0000-0000000000a1</Id>
+          <EditRate>24 1</EditRate>
+          <IntrinsicDuration>2400</IntrinsicDuration>
+        </MainPicture>
+      </AssetList>
+    </Reel>
+  </ReelList>
+</CompositionPlaylist>`
+
+func newTestServer(t *testing.T) *moviebufftest.Server {
+	s := moviebufftest.NewServer("token")
+
+	movie := moviebufftest.NewMovie("m1", "Baahubali 2: The Conclusion").
+		Language("Telugu").
+		Genres("Action", "Drama").
+		RunningTime(167*time.Minute).
+		ReleaseDate("IN", "2017-04-28").
+		Certification("IN", "UA").
+		Director("S. S. Rajamouli", "p2").
+		Build()
+	if err := s.AddMovie(movie); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddPerson(moviebufftest.NewPerson("p1", "Prabhas").Birthday("1979-10-23").Build()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddEntity(moviebufftest.NewEntity("e1", "Arka Media Works").Services("Production").Build()); err != nil {
+		t.Fatal(err)
+	}
+	s.AddCertifications(moviebuff.Certification{Code: "UA", UUID: "ua-uuid", Country: moviebuff.Country{Code: "IN"}})
+	s.AddHolidayCalendar("IN", &moviebuff.Calendar{Name: "India", TimeZone: "Asia/Kolkata",
+		Holidays: []moviebuff.Holiday{{ID: "diwali", Name: "Diwali", Date: "2024-11-01"}}})
+	s.AddLanguages(moviebuff.Language{UUID: "te-uuid", Name: "Telugu", ISO639_1: "te", ISO639_2: "tel"})
+	s.AddMappedCPL(&moviebuff.MappedCPL{ID: 42, UUID: "ab9754d6-b1cf-4185-8554-4fc505670d7f",
+		Movie: moviebuff.MappedCPLMovie{UUID: "m1", Name: "Baahubali 2: The Conclusion"}})
+	return s
+}
+
+func TestRun(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Close()
+
+	dir, err := ioutil.TempDir("", "moviebuff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cplPath := filepath.Join(dir, "cpl.xml")
+	if err := ioutil.WriteFile(cplPath, []byte(testCPL), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	env := map[string]string{
+		ENV_TOKEN:    "token",
+		ENV_HOST_URL: s.URL,
+		ENV_CONFIG:   "",
+	}
+
+	var testCases = []struct {
+		desc         string
+		args         []string
+		env          map[string]string
+		expectedCode int
+		expectedOut  string
+		expectedErr  string
+	}{
+		{
+			desc:         "movie as template",
+			args:         []string{"movie", "-format", "{{.Name}} ({{.Language}}) {{runningTime .}}", "m1"},
+			expectedCode: EXIT_OK,
+			expectedOut:  "Baahubali 2: The Conclusion (Telugu) 2h 47m\n",
+		}, {
+			desc:         "movie as table",
+			args:         []string{"movie", "-format", "table", "baahubali-2-the-conclusion"},
+			expectedCode: EXIT_OK,
+			expectedOut: "FIELD           VALUE\n" +
+				"Name            Baahubali 2: The Conclusion\n" +
+				"UUID            m1\n" +
+				"URL             baahubali-2-the-conclusion\n" +
+				"Film type       \n" +
+				"Language        Telugu\n" +
+				"Genres          Action, Drama\n" +
+				"Running time    2h 47m\n" +
+				"Release date    2017-04-28\n" +
+				"Certifications  IN: UA\n" +
+				"Directors       S. S. Rajamouli\n" +
+				"Cast            \n",
+		}, {
+			desc:         "movie not found",
+			args:         []string{"movie", "unknown"},
+			expectedCode: EXIT_ERROR,
+			expectedErr:  "moviebuff: resource does not exist\n",
+		}, {
+			desc:         "movie without id",
+			args:         []string{"movie"},
+			expectedCode: EXIT_USAGE,
+		}, {
+			desc:         "person as template",
+			args:         []string{"person", "-format", "{{.Name}} {{.Birthday}}", "p1"},
+			expectedCode: EXIT_OK,
+			expectedOut:  "Prabhas 1979-10-23\n",
+		}, {
+			desc:         "entity as template",
+			args:         []string{"entity", "-format", `{{join .Services ","}}`, "e1"},
+			expectedCode: EXIT_OK,
+			expectedOut:  "Production\n",
+		}, {
+			desc:         "list as table",
+			args:         []string{"list", "-format", "table", "-limit", "1", "people"},
+			expectedCode: EXIT_OK,
+			expectedOut:  "NAME     TYPE    URL      UUID\nPrabhas  person  prabhas  p1\n",
+		}, {
+			desc:         "list of unknown type",
+			args:         []string{"list", "songs"},
+			expectedCode: EXIT_ERROR,
+			expectedErr:  "moviebuff: unknown resource type \"songs\", expected movies, people or entities\n",
+		}, {
+			desc:         "certifications as table",
+			args:         []string{"certifications", "-format", "table", "IN"},
+			expectedCode: EXIT_OK,
+			expectedOut:  "COUNTRY  CODE  CHILD SAFE  UUID\nIN       UA    false       ua-uuid\n",
+		}, {
+			desc:         "holidays as table",
+			args:         []string{"holidays", "-format", "table", "IN"},
+			expectedCode: EXIT_OK,
+			expectedOut:  "DATE        NAME    ID\n2024-11-01  Diwali  diwali\n",
+		}, {
+			desc:         "languages as template",
+			args:         []string{"languages", "-format", "{{range .}}{{.ISO639_2}}{{end}}"},
+			expectedCode: EXIT_OK,
+			expectedOut:  "tel\n",
+		}, {
+			desc:         "cpl by id",
+			args:         []string{"cpl", "-format", "{{.Movie.Name}}", "ab9754d6-b1cf-4185-8554-4fc505670d7f"},
+			expectedCode: EXIT_OK,
+			expectedOut:  "Baahubali 2: The Conclusion\n",
+		}, {
+			desc:         "cpl file",
+			args:         []string{"cpl", "-format", "{{range .Results}}{{.Status}} {{.Mapped.Movie.UUID}}{{end}}", cplPath},
+			expectedCode: EXIT_OK,
+			expectedOut:  "mapped m1\n",
+		}, {
+			desc:         "cpl directory",
+			args:         []string{"cpl", "-format", "{{len .Results}}", dir},
+			expectedCode: EXIT_OK,
+			expectedOut:  "1\n",
+		}, {
+			desc:         "missing cpl file",
+			args:         []string{"cpl", filepath.Join(dir, "cpl.xlm")},
+			expectedCode: EXIT_ERROR,
+			expectedErr:  "moviebuff: stat " + filepath.Join(dir, "cpl.xlm") + ": no such file or directory\n",
+		}, {
+			desc:         "token flag overrides environment",
+			args:         []string{"languages", "-token", "wrong"},
+			expectedCode: EXIT_ERROR,
+			expectedErr:  "moviebuff: access denied\n",
+		}, {
+			desc:         "missing token",
+			args:         []string{"languages"},
+			env:          map[string]string{ENV_HOST_URL: s.URL, ENV_CONFIG: ""},
+			expectedCode: EXIT_USAGE,
+			expectedErr:  "moviebuff: no API key: use -token, MOVIEBUFF_TOKEN or the configuration file\n",
+		}, {
+			desc:         "invalid template",
+			args:         []string{"languages", "-format", "{{.Name"},
+			expectedCode: EXIT_USAGE,
+		}, {
+			desc:         "unknown command",
+			args:         []string{"songs"},
+			expectedCode: EXIT_USAGE,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			vars := env
+			if testCase.env != nil {
+				vars = testCase.env
+			}
+			var stdout, stderr bytes.Buffer
+			code := run(context.Background(), testCase.args, func(key string) string { return vars[key] }, &stdout, &stderr)
+
+			assert.Equal(testCase.expectedCode, code, stderr.String())
+			if testCase.expectedCode == EXIT_OK {
+				assert.Equal(testCase.expectedOut, stdout.String())
+			}
+			if testCase.expectedErr != "" {
+				assert.Equal(testCase.expectedErr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRun_JSON(t *testing.T) {
+	assert := assert.New(t)
+
+	s := newTestServer(t)
+	defer s.Close()
+
+	var stdout, stderr bytes.Buffer
+	getenv := func(key string) string {
+		return map[string]string{ENV_TOKEN: "token", ENV_HOST_URL: s.URL, ENV_CONFIG: ""}[key]
+	}
+	code := run(context.Background(), []string{"movie", "m1"}, getenv, &stdout, &stderr)
+	if !assert.Equal(EXIT_OK, code, stderr.String()) {
+		return
+	}
+
+	var m moviebuff.Movie
+	if assert.NoError(json.Unmarshal(stdout.Bytes(), &m)) {
+		assert.Equal("Baahubali 2: The Conclusion", m.Name)
+		assert.Equal("movie", m.Type)
+	}
+	assert.Contains(stdout.String(), "\n  \"name\": \"Baahubali 2: The Conclusion\",\n")
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "moviebuff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+	if err := ioutil.WriteFile(path, []byte(`{"token": "file-token", "hostUrl": "https://file.example.com"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var testCases = []struct {
+		desc           string
+		path           string
+		env            map[string]string
+		expectedConfig *config
+		expectedErr    bool
+	}{
+		{
+			desc:           "file",
+			path:           path,
+			expectedConfig: &config{Token: "file-token", HostURL: "https://file.example.com"},
+		}, {
+			desc:           "file from environment",
+			env:            map[string]string{ENV_CONFIG: path},
+			expectedConfig: &config{Token: "file-token", HostURL: "https://file.example.com"},
+		}, {
+			desc:           "environment overrides file",
+			path:           path,
+			env:            map[string]string{ENV_TOKEN: "env-token"},
+			expectedConfig: &config{Token: "env-token", HostURL: "https://file.example.com"},
+		}, {
+			desc:        "missing file",
+			path:        filepath.Join(dir, "missing.json"),
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			c, err := loadConfig(testCase.path, func(key string) string { return testCase.env[key] })
+			if testCase.expectedErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(testCase.expectedConfig, c)
+		})
+	}
+}
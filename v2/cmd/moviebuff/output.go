@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+	"github.com/RealImage/moviebuff-sdk-go/v2/cpl"
+)
+
+// Output formats. Any other format is a Go template.
+const (
+	FORMAT_JSON  = "json"
+	FORMAT_TABLE = "table"
+)
+
+// output writes the results of commands.
+type output struct {
+	format   string
+	template *template.Template
+}
+
+// templateFuncs are the functions available to templates in addition to the builtin ones.
+var templateFuncs = template.FuncMap{
+	"join": strings.Join,
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"runningTime": func(m *moviebuff.Movie) string {
+		return m.GetFormattedRunningTime()
+	},
+}
+
+// newOutput returns the output of the given format, parsing it if it is a template.
+func newOutput(format string) (*output, error) {
+	switch format {
+	case FORMAT_JSON, FORMAT_TABLE:
+		return &output{format: format}, nil
+	}
+
+	t, err := template.New("format").Funcs(templateFuncs).Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format: %w", err)
+	}
+	return &output{template: t}, nil
+}
+
+// write writes the result v to w.
+func (o *output) write(w io.Writer, v interface{}) error {
+	switch {
+	case o.template != nil:
+		var b strings.Builder
+		if err := o.template.Execute(&b, v); err != nil {
+			return err
+		}
+		s := b.String()
+		if !strings.HasSuffix(s, "\n") {
+			s += "\n"
+		}
+		_, err := io.WriteString(w, s)
+		return err
+
+	case o.format == FORMAT_TABLE:
+		header, rows := table(v)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+		for _, row := range rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+
+	default:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(v)
+	}
+}
+
+// table returns the header and the rows of the compact table of a result.
+// Single resources are printed as a field per row.
+func table(v interface{}) (header []string, rows [][]string) {
+	fields := []string{"FIELD", "VALUE"}
+
+	switch v := v.(type) {
+	case *moviebuff.Movie:
+		releaseDate := ""
+		if d, ok := v.GetEarliestReleaseDate(); ok {
+			releaseDate = d.Format("2006-01-02")
+		}
+		return fields, [][]string{
+			{"Name", v.Name},
+			{"UUID", v.UUID},
+			{"URL", v.URL},
+			{"Film type", v.FilmType},
+			{"Language", v.Language},
+			{"Genres", strings.Join(v.Genres, ", ")},
+			{"Running time", v.GetFormattedRunningTime()},
+			{"Release date", releaseDate},
+			{"Certifications", joinMap(v.Certifications)},
+			{"Directors", joinCredits(v.GetDirectors())},
+			{"Cast", joinCredits(v.GetPrimaryCast())},
+		}
+
+	case *moviebuff.Person:
+		credits := 0
+		for _, c := range v.Credits {
+			credits += len(c.Roles)
+		}
+		return fields, [][]string{
+			{"Name", v.Name},
+			{"UUID", v.UUID},
+			{"URL", v.URL},
+			{"Birthday", v.Birthday},
+			{"Birthplace", v.Birthplace},
+			{"Credits", strconv.Itoa(credits)},
+		}
+
+	case *moviebuff.Entity:
+		credits := 0
+		for _, c := range v.Credits {
+			credits += len(c.Roles)
+		}
+		return fields, [][]string{
+			{"Name", v.Name},
+			{"UUID", v.UUID},
+			{"URL", v.URL},
+			{"Services", strings.Join(v.Services, ", ")},
+			{"Credits", strconv.Itoa(credits)},
+		}
+
+	case *moviebuff.Resources:
+		for _, r := range v.Data {
+			rows = append(rows, []string{r.Name, r.Type, r.URL, r.UUID})
+		}
+		return []string{"NAME", "TYPE", "URL", "UUID"}, rows
+
+	case []moviebuff.Certification:
+		for _, c := range v {
+			rows = append(rows, []string{c.Country.Code, c.Code, strconv.FormatBool(c.ChildSafe), c.UUID})
+		}
+		return []string{"COUNTRY", "CODE", "CHILD SAFE", "UUID"}, rows
+
+	case *moviebuff.Calendar:
+		for _, h := range v.Holidays {
+			rows = append(rows, []string{h.Date, h.Name, h.ID})
+		}
+		return []string{"DATE", "NAME", "ID"}, rows
+
+	case []moviebuff.Language:
+		for _, l := range v {
+			rows = append(rows, []string{l.Name, l.ISO639_1, l.ISO639_2, l.NativeName, l.UUID})
+		}
+		return []string{"NAME", "ISO 639-1", "ISO 639-2", "NATIVE NAME", "UUID"}, rows
+
+	case *moviebuff.MappedCPL:
+		part := ""
+		if v.Movie.Part != nil {
+			part = v.Movie.Part.Name
+		}
+		return fields, [][]string{
+			{"CPL", v.UUID},
+			{"Content title", v.ContentTitleText},
+			{"Movie", v.Movie.Name},
+			{"Movie UUID", v.Movie.UUID},
+			{"Part", part},
+		}
+
+	case *cpl.Report:
+		for _, r := range v.Results {
+			var movie, part string
+			if r.Mapped != nil {
+				movie = r.Mapped.Movie.Name
+				if r.Mapped.Movie.Part != nil {
+					part = r.Mapped.Movie.Part.Name
+				}
+			}
+			rows = append(rows, []string{r.Path, r.CPLID, string(r.Status), movie, part, r.Error})
+		}
+		for _, f := range v.Invalid {
+			rows = append(rows, []string{f.Path, "", "invalid", "", "", f.Error})
+		}
+		return []string{"PATH", "CPL", "STATUS", "MOVIE", "PART", "ERROR"}, rows
+	}
+	return fields, [][]string{{"Value", fmt.Sprint(v)}}
+}
+
+// joinMap returns the values of m prefixed by their key, sorted by key, like "IN: UA, US: R".
+func joinMap(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		values[i] = k + ": " + m[k]
+	}
+	return strings.Join(values, ", ")
+}
+
+// joinCredits returns the names of the credits separated by commas.
+func joinCredits(credits []moviebuff.Credit) string {
+	names := make([]string, len(credits))
+	for i, c := range credits {
+		names[i] = c.Name
+	}
+	return strings.Join(names, ", ")
+}
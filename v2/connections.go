@@ -0,0 +1,194 @@
+package moviebuff
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// TraverseOptions controls how connected movies are fetched by TraverseConnections.
+type TraverseOptions struct {
+	// MaxDepth is the number of hops followed from the root movie. Defaults to 1.
+	MaxDepth int
+
+	// Concurrency is the maximum number of movies fetched at once. Defaults to 4.
+	Concurrency int
+
+	// ConnectionTypes restricts the connections followed, like "Sequel" or "Remake".
+	// Connection types are compared ignoring case and punctuation. All connections are followed if empty.
+	ConnectionTypes []string
+}
+
+// ConnectedMovie is a movie reached while traversing the connections of a root movie.
+type ConnectedMovie struct {
+	Movie *Movie
+
+	// Depth is the number of hops from the root movie.
+	Depth int
+
+	// ConnectionType of the connection the movie was reached through.
+	ConnectionType string
+
+	// From is the UUID of the movie the connection was listed in.
+	From string
+}
+
+// ConnectionGraph is the result of traversing the connections of a movie.
+type ConnectionGraph struct {
+	Root *Movie
+
+	// Movies reached from the root, in breadth first order. The root is not included.
+	Movies []ConnectedMovie
+}
+
+// TraverseConnections fetches the movie with the given id and its connected movies recursively,
+// up to opts.MaxDepth hops away. Every movie is fetched once, even if it is connected to several movies.
+//
+// Connections which do not exist anymore are skipped. Any other error stops the traversal
+// and is returned along with the movies fetched so far.
+func TraverseConnections(ctx context.Context, mb Moviebuff, id string, opts TraverseOptions) (*ConnectionGraph, error) {
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = 1
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+
+	root, err := mb.GetMovie(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &ConnectionGraph{Root: root}
+	visited := map[string]bool{root.UUID: true}
+	level := []*Movie{root}
+
+	for depth := 1; depth <= opts.MaxDepth && len(level) > 0; depth++ {
+		var pending []ConnectedMovie
+		for _, m := range level {
+			for _, c := range m.Connections {
+				if c.UUID == "" || visited[c.UUID] || !opts.follows(c.ConnectionType) {
+					continue
+				}
+				visited[c.UUID] = true
+				pending = append(pending, ConnectedMovie{
+					Movie:          &Movie{UUID: c.UUID},
+					Depth:          depth,
+					ConnectionType: c.ConnectionType,
+					From:           m.UUID,
+				})
+			}
+		}
+
+		fetched, err := fetchConnected(ctx, mb, pending, opts.Concurrency)
+		graph.Movies = append(graph.Movies, fetched...)
+		if err != nil {
+			return graph, err
+		}
+
+		level = level[:0]
+		for _, c := range fetched {
+			level = append(level, c.Movie)
+		}
+	}
+
+	return graph, nil
+}
+
+func (opts TraverseOptions) follows(connectionType string) bool {
+	if len(opts.ConnectionTypes) == 0 {
+		return true
+	}
+	for _, t := range opts.ConnectionTypes {
+		if normalizeName(t) == normalizeName(connectionType) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchConnected fetches the pending movies with at most concurrency requests at once.
+// Movies which do not exist are dropped. The first other error cancels the remaining requests.
+func fetchConnected(ctx context.Context, mb Moviebuff, pending []ConnectedMovie, concurrency int) ([]ConnectedMovie, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	movies := make([]*Movie, len(pending))
+	var firstErr error
+	var once sync.Once
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				m, err := mb.GetMovie(ctx, pending[i].Movie.UUID)
+				if errors.Is(err, ErrResourceDoesNotExist) {
+					continue
+				}
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				movies[i] = m
+			}
+		}()
+	}
+
+	for i := range pending {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var fetched []ConnectedMovie
+	for i, m := range movies {
+		if m == nil {
+			continue
+		}
+		c := pending[i]
+		c.Movie = m
+		fetched = append(fetched, c)
+	}
+	return fetched, firstErr
+}
+
+// ByConnectionType returns the connected movies grouped by the type of connection they were reached through.
+func (g *ConnectionGraph) ByConnectionType() map[string][]ConnectedMovie {
+	groups := map[string][]ConnectedMovie{}
+	for _, c := range g.Movies {
+		groups[c.ConnectionType] = append(groups[c.ConnectionType], c)
+	}
+	return groups
+}
+
+// Timeline returns the root and the connected movies ordered by their earliest release date,
+// like the timeline of a franchise. If connectionTypes are given, only movies reached through
+// those types of connections are included, compared ignoring case and punctuation.
+// Movies without a release date are moved to the end.
+func (g *ConnectionGraph) Timeline(connectionTypes ...string) []*Movie {
+	filter := TraverseOptions{ConnectionTypes: connectionTypes}
+
+	timeline := []*Movie{g.Root}
+	for _, c := range g.Movies {
+		if filter.follows(c.ConnectionType) {
+			timeline = append(timeline, c.Movie)
+		}
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		ti, iok := timeline[i].GetEarliestReleaseDate()
+		tj, jok := timeline[j].GetEarliestReleaseDate()
+		if iok != jok {
+			return iok
+		}
+		return ti.Before(tj)
+	})
+	return timeline
+}
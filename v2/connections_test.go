@@ -0,0 +1,76 @@
+package moviebuff
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var connectedMovies = map[string]string{
+	"a": `{"uuid": "a", "name": "A", "type": "movie", "releaseDates": {"IN": "2015-07-10"},
+		"connections": [{"uuid": "b", "connectionType": "Sequel"}, {"uuid": "gone", "connectionType": "Remake"}]}`,
+	"b": `{"uuid": "b", "name": "B", "type": "movie", "releaseDates": {"IN": "2017-04-28"},
+		"connections": [{"uuid": "a", "connectionType": "Prequel"}, {"uuid": "c", "connectionType": "Dubbed Version"}]}`,
+	"c": `{"uuid": "c", "name": "C", "type": "movie", "releaseDates": {"IN": "2014-04-28"},
+		"connections": [{"uuid": "d", "connectionType": "Sequel"}]}`,
+	"d": `{"uuid": "d", "name": "D", "type": "movie"}`,
+}
+
+func TestTraverseConnections(t *testing.T) {
+	assert := assert.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter,
+		r *http.Request) {
+		body, ok := connectedMovies[strings.TrimPrefix(r.URL.Path, "/resources/movies/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer ts.Close()
+
+	mb := New(Config{
+		HostURL:     ts.URL,
+		StaticToken: "staticToken",
+	})
+
+	graph, err := TraverseConnections(context.Background(), mb, "a", TraverseOptions{MaxDepth: 2})
+	assert.NoError(err)
+	assert.Equal("A", graph.Root.Name)
+	assert.Len(graph.Movies, 2)
+	assert.Equal("B", graph.Movies[0].Movie.Name)
+	assert.Equal(1, graph.Movies[0].Depth)
+	assert.Equal("C", graph.Movies[1].Movie.Name)
+	assert.Equal(2, graph.Movies[1].Depth)
+	assert.Equal("b", graph.Movies[1].From)
+
+	groups := graph.ByConnectionType()
+	assert.Len(groups["Sequel"], 1)
+	assert.Len(groups["Dubbed Version"], 1)
+
+	var timeline []string
+	for _, m := range graph.Timeline() {
+		timeline = append(timeline, m.Name)
+	}
+	assert.Equal([]string{"C", "A", "B"}, timeline)
+
+	timeline = nil
+	for _, m := range graph.Timeline("sequel", "prequel") {
+		timeline = append(timeline, m.Name)
+	}
+	assert.Equal([]string{"A", "B"}, timeline)
+
+	graph, err = TraverseConnections(context.Background(), mb, "a", TraverseOptions{MaxDepth: 5, ConnectionTypes: []string{"sequel"}})
+	assert.NoError(err)
+	assert.Len(graph.Movies, 1)
+
+	graph, err = TraverseConnections(context.Background(), mb, "a", TraverseOptions{MaxDepth: 5, Concurrency: 1})
+	assert.NoError(err)
+	assert.Len(graph.Movies, 3)
+	assert.Equal("D", graph.Timeline()[3].Name)
+}
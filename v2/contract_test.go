@@ -0,0 +1,171 @@
+package moviebuff
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// goldenFixtures are full API responses of every model, mapped against the value they decode into.
+var goldenFixtures = []struct {
+	fixture  string
+	newValue func() interface{}
+}{
+	{fixture: "movie.json", newValue: func() interface{} { return new(Movie) }},
+	{fixture: "person.json", newValue: func() interface{} { return new(Person) }},
+	{fixture: "entity.json", newValue: func() interface{} { return new(Entity) }},
+	{fixture: "resources.json", newValue: func() interface{} { return new(Resources) }},
+	{fixture: "certifications.json", newValue: func() interface{} {
+		return new(struct {
+			Data []Certification `json:"data"`
+		})
+	}},
+	{fixture: "calendar.json", newValue: func() interface{} { return new(Calendar) }},
+	{fixture: "languages.json", newValue: func() interface{} { return new([]Language) }},
+	{fixture: "mapped_cpl.json", newValue: func() interface{} { return new(MappedCPL) }},
+}
+
+func readGoldenFixture(tb testing.TB, name string) []byte {
+	tb.Helper()
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "golden", name))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return data
+}
+
+func TestGoldenFixtures(t *testing.T) {
+	for _, testCase := range goldenFixtures {
+		t.Run(testCase.fixture, func(t *testing.T) {
+			assert := assert.New(t)
+			data := readGoldenFixture(t, testCase.fixture)
+
+			// Every field of the fixture must be known to the model.
+			v := testCase.newValue()
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			decoder.DisallowUnknownFields()
+			if !assert.NoError(decoder.Decode(v)) {
+				return
+			}
+
+			// Encoding the model must give back the fixture, so that no field is lost or added.
+			encoded, err := json.Marshal(v)
+			if !assert.NoError(err) {
+				return
+			}
+			assert.JSONEq(string(data), string(encoded))
+
+			// Decoding the encoded model must give back the same model.
+			decoded := testCase.newValue()
+			if assert.NoError(json.Unmarshal(encoded, decoded)) {
+				assert.Equal(v, decoded)
+			}
+		})
+	}
+}
+
+func TestGoldenFixtures_Movie(t *testing.T) {
+	assert := assert.New(t)
+
+	var m Movie
+	if !assert.NoError(json.Unmarshal(readGoldenFixture(t, "movie.json"), &m)) {
+		return
+	}
+
+	assert.Equal("Baahubali 2: The Conclusion", m.Name)
+	assert.Equal("Telugu", m.LanguageData.Name)
+	assert.Equal("2017-04-28", m.ReleaseDates["IN"])
+	assert.Equal("UA", m.Certifications["IN"])
+	assert.Equal(10020, m.RunningTime)
+	assert.Equal("qD-6d8Wo3do", m.Trailer.Key)
+	assert.Equal([]interface{}{"2.39:1"}, m.TechDetails[0].Data)
+	assert.Equal("Dolby Atmos", m.TechDetails[1].Data)
+	assert.Equal(1200, m.MovieRating.Count)
+	assert.Equal("Amarendra Baahubali", m.Cast[0].Character)
+	assert.Equal("Director", m.Crew[0].Roles[0].Role)
+	assert.Equal("Lahari Music", m.MusicLabels[0].Name)
+	assert.Equal("Staff", m.News[0].Writer)
+	assert.Equal("Prequel", m.Connections[0].ConnectionType)
+	assert.Equal("Released", m.ReleaseStatuses.AE)
+	assert.Equal(RELEASE_STATUS_UPCOMING, m.ReleaseStatuses.Get("US"))
+	assert.Equal(RELEASE_STATUS_RELEASED, m.ReleaseStatuses.Get("IN"))
+	assert.Equal("IMDb", m.ThirdPartyIdentifiers[0].Source.Name)
+	assert.Equal([]string{"tt4849438"}, m.ThirdPartyIdentifiers[0].IDs)
+}
+
+func TestGoldenFixtures_Person(t *testing.T) {
+	assert := assert.New(t)
+
+	var p Person
+	if !assert.NoError(json.Unmarshal(readGoldenFixture(t, "person.json"), &p)) {
+		return
+	}
+
+	assert.Equal("Prabhas", p.Name)
+	assert.Equal("1979-10-23", p.Birthday)
+	assert.Equal([]string{"Darling"}, p.Akas)
+	assert.Equal("Twitter", p.Links[0].Name)
+	assert.Equal("Cast", p.Credits[0].Department)
+	assert.Equal("Amarendra Baahubali", p.Credits[0].Roles[0].Character)
+	assert.Equal("2017-04-28", p.Credits[0].Roles[0].ReleaseDates["IN"])
+}
+
+func TestGoldenFixtures_Entity(t *testing.T) {
+	assert := assert.New(t)
+
+	var e Entity
+	if !assert.NoError(json.Unmarshal(readGoldenFixture(t, "entity.json"), &e)) {
+		return
+	}
+
+	assert.Equal("Arka Media Works", e.Name)
+	assert.Equal([]string{"Production"}, e.Services)
+	assert.Equal("Production Company", e.Credits[0].Roles[0].Role)
+	assert.Equal(TolerantString("https://assets.moviebuff.com/posters/baahubali-2.jpg"), e.Credits[0].Roles[0].Poster)
+	assert.Equal("Office", e.Stills[0].Caption)
+}
+
+func TestGoldenFixtures_Others(t *testing.T) {
+	assert := assert.New(t)
+
+	var resources Resources
+	if assert.NoError(json.Unmarshal(readGoldenFixture(t, "resources.json"), &resources)) {
+		assert.Equal("/api/v2/resources/movies?limit=2&page=3", resources.Next)
+		assert.Equal("baahubali-2-the-conclusion", resources.Data[0].URL)
+	}
+
+	var certifications struct {
+		Data []Certification `json:"data"`
+	}
+	if assert.NoError(json.Unmarshal(readGoldenFixture(t, "certifications.json"), &certifications)) {
+		assert.Len(certifications.Data, 2)
+		assert.True(certifications.Data[0].ChildSafe)
+		assert.Equal("IN", certifications.Data[1].Country.Code)
+	}
+
+	var calendar Calendar
+	if assert.NoError(json.Unmarshal(readGoldenFixture(t, "calendar.json"), &calendar)) {
+		assert.Equal("Asia/Kolkata", calendar.TimeZone)
+		assert.Equal("confirmed", calendar.Holidays[0].Status)
+	}
+
+	var languages []Language
+	if assert.NoError(json.Unmarshal(readGoldenFixture(t, "languages.json"), &languages)) {
+		assert.Len(languages, 2)
+		assert.Equal("tel", languages[0].ISO639_2)
+		assert.Equal([]string{"IN", "LK", "SG"}, languages[1].SpokenIn)
+	}
+
+	var mapped MappedCPL
+	if assert.NoError(json.Unmarshal(readGoldenFixture(t, "mapped_cpl.json"), &mapped)) {
+		assert.Equal(476829, mapped.ID)
+		assert.Equal("Andhra King Taluka", mapped.Movie.Name)
+		if assert.NotNil(mapped.Movie.Part) {
+			assert.Equal("Part 2", mapped.Movie.Part.Name)
+		}
+	}
+}
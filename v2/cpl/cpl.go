@@ -0,0 +1,359 @@
+// Package cpl reads Digital Cinema Composition Playlists (CPL) and maps them to Moviebuff movies.
+//
+// Both Interop and SMPTE (ST 429-7) CPLs are supported. A CPL lists the reels of a composition
+// and the picture, sound, subtitle and other track files played in every reel.
+package cpl
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// XML namespaces of Composition Playlists
+const (
+	NAMESPACE_INTEROP = "http://www.digicine.com/PROTO-ASDCP-CPL-20040511#"
+	NAMESPACE_SMPTE   = "http://www.smpte-ra.org/schemas/429-7/2006/CPL"
+)
+
+// Standard of a Composition Playlist
+type Standard string
+
+const (
+	STANDARD_INTEROP Standard = "Interop"
+	STANDARD_SMPTE   Standard = "SMPTE"
+)
+
+// Kind of assets, the local name of their element in the asset list of a reel
+const (
+	ASSET_MAIN_PICTURE              = "MainPicture"
+	ASSET_MAIN_STEREOSCOPIC_PICTURE = "MainStereoscopicPicture"
+	ASSET_MAIN_SOUND                = "MainSound"
+	ASSET_MAIN_SUBTITLE             = "MainSubtitle"
+	ASSET_MAIN_CLOSED_CAPTION       = "MainClosedCaption"
+	ASSET_MAIN_MARKERS              = "MainMarkers"
+	ASSET_AUX_DATA                  = "AuxData"
+)
+
+const uuidURNPrefix = "urn:uuid:"
+
+var ErrNotCompositionPlaylist = errors.New("cpl: not a composition playlist")
+
+// CompositionPlaylist is a parsed CPL.
+type CompositionPlaylist struct {
+	Standard Standard
+
+	// ID is the UUID of the CPL, without its "urn:uuid:" prefix.
+	ID string
+
+	AnnotationText   string
+	IssueDate        string
+	Issuer           string
+	Creator          string
+	ContentTitleText string
+
+	// ContentKind is like "feature" or "trailer".
+	ContentKind string
+
+	Reels []Reel
+}
+
+// Reel is a reel of a composition and the assets played in it.
+type Reel struct {
+	// ID is the UUID of the reel, without its "urn:uuid:" prefix.
+	ID     string
+	Assets []Asset
+}
+
+// Asset is a track file played in a reel.
+type Asset struct {
+	// Kind is the local name of the asset's element, like "MainPicture".
+	Kind string
+
+	// ID is the UUID of the track file, without its "urn:uuid:" prefix.
+	ID string
+
+	AnnotationText string
+	EditRate       EditRate
+
+	// IntrinsicDuration is the number of edit units in the track file,
+	// EntryPoint the first edit unit played and Duration the number of edit units played.
+	// Duration is zero if the CPL does not state it, in which case the asset plays till its end.
+	IntrinsicDuration int64
+	EntryPoint        int64
+	Duration          int64
+
+	// KeyID is the UUID of the key of an encrypted track file, without its "urn:uuid:" prefix.
+	KeyID string
+	Hash  string
+}
+
+// EditRate is a rate in edit units per second, written as "24 1" in CPLs.
+type EditRate struct {
+	Numerator   int64
+	Denominator int64
+}
+
+// ParseEditRate parses an edit rate written as numerator and denominator separated by spaces, like "24 1".
+func ParseEditRate(s string) (EditRate, error) {
+	parts := strings.Fields(s)
+	if len(parts) != 2 {
+		return EditRate{}, fmt.Errorf("cpl: invalid edit rate %q", s)
+	}
+	num, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return EditRate{}, fmt.Errorf("cpl: invalid edit rate %q: %v", s, err)
+	}
+	den, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || den <= 0 {
+		return EditRate{}, fmt.Errorf("cpl: invalid edit rate %q", s)
+	}
+	return EditRate{Numerator: num, Denominator: den}, nil
+}
+
+func (r EditRate) String() string {
+	return fmt.Sprintf("%d %d", r.Numerator, r.Denominator)
+}
+
+// Float returns the edit rate in edit units per second. Zero is returned for a zero edit rate.
+func (r EditRate) Float() float64 {
+	if r.Denominator == 0 {
+		return 0
+	}
+	return float64(r.Numerator) / float64(r.Denominator)
+}
+
+// Duration returns the time taken to play n edit units at the edit rate.
+func (r EditRate) Duration(n int64) time.Duration {
+	if r.Numerator == 0 {
+		return 0
+	}
+	return time.Duration(n * r.Denominator * int64(time.Second) / r.Numerator)
+}
+
+// PlayedDuration returns the number of edit units played.
+func (a Asset) PlayedDuration() int64 {
+	if a.Duration > 0 {
+		return a.Duration
+	}
+	return a.IntrinsicDuration - a.EntryPoint
+}
+
+// Length returns the time the asset plays for.
+func (a Asset) Length() time.Duration {
+	return a.EditRate.Duration(a.PlayedDuration())
+}
+
+// Encrypted reports whether the track file is encrypted.
+func (a Asset) Encrypted() bool {
+	return a.KeyID != ""
+}
+
+// Picture returns the main picture of the reel, stereoscopic or not. False is returned if it has none.
+func (r Reel) Picture() (Asset, bool) {
+	for _, a := range r.Assets {
+		if a.Kind == ASSET_MAIN_PICTURE || a.Kind == ASSET_MAIN_STEREOSCOPIC_PICTURE {
+			return a, true
+		}
+	}
+	return Asset{}, false
+}
+
+// Length returns the time the reel plays for, the length of its picture or of its first asset if it has no picture.
+func (r Reel) Length() time.Duration {
+	if a, ok := r.Picture(); ok {
+		return a.Length()
+	}
+	if len(r.Assets) > 0 {
+		return r.Assets[0].Length()
+	}
+	return 0
+}
+
+// Length returns the time the composition plays for.
+func (c *CompositionPlaylist) Length() time.Duration {
+	var length time.Duration
+	for _, r := range c.Reels {
+		length += r.Length()
+	}
+	return length
+}
+
+// EditRate returns the edit rate of the first picture of the composition.
+func (c *CompositionPlaylist) EditRate() EditRate {
+	for _, r := range c.Reels {
+		if a, ok := r.Picture(); ok {
+			return a.EditRate
+		}
+	}
+	return EditRate{}
+}
+
+// AssetIDs returns the IDs of the track files of the composition, in order of play and without duplicates.
+func (c *CompositionPlaylist) AssetIDs() []string {
+	seen := map[string]bool{}
+	var ids []string
+	for _, r := range c.Reels {
+		for _, a := range r.Assets {
+			if a.ID != "" && !seen[a.ID] {
+				seen[a.ID] = true
+				ids = append(ids, a.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// Is3D reports whether the composition has a stereoscopic picture.
+func (c *CompositionPlaylist) Is3D() bool {
+	for _, r := range c.Reels {
+		for _, a := range r.Assets {
+			if a.Kind == ASSET_MAIN_STEREOSCOPIC_PICTURE {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Encrypted reports whether any track file of the composition is encrypted.
+func (c *CompositionPlaylist) Encrypted() bool {
+	for _, r := range c.Reels {
+		for _, a := range r.Assets {
+			if a.Encrypted() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// XML representation of a CPL. Elements are matched by local name, so that both namespaces are accepted.
+type xmlCPL struct {
+	XMLName          xml.Name
+	ID               string    `xml:"Id"`
+	AnnotationText   string    `xml:"AnnotationText"`
+	IssueDate        string    `xml:"IssueDate"`
+	Issuer           string    `xml:"Issuer"`
+	Creator          string    `xml:"Creator"`
+	ContentTitleText string    `xml:"ContentTitleText"`
+	ContentKind      string    `xml:"ContentKind"`
+	Reels            []xmlReel `xml:"ReelList>Reel"`
+}
+
+type xmlReel struct {
+	ID        string       `xml:"Id"`
+	AssetList xmlAssetList `xml:"AssetList"`
+}
+
+// xmlAssetList decodes every child element of an asset list as an asset, whatever its name.
+type xmlAssetList struct {
+	Assets []Asset
+}
+
+type xmlAsset struct {
+	ID                string `xml:"Id"`
+	AnnotationText    string `xml:"AnnotationText"`
+	EditRate          string `xml:"EditRate"`
+	IntrinsicDuration int64  `xml:"IntrinsicDuration"`
+	EntryPoint        int64  `xml:"EntryPoint"`
+	Duration          int64  `xml:"Duration"`
+	KeyID             string `xml:"KeyId"`
+	Hash              string `xml:"Hash"`
+}
+
+func (l *xmlAssetList) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var a xmlAsset
+			if err := d.DecodeElement(&a, &t); err != nil {
+				return err
+			}
+			asset := Asset{
+				Kind:              t.Name.Local,
+				ID:                trimUUID(a.ID),
+				AnnotationText:    strings.TrimSpace(a.AnnotationText),
+				IntrinsicDuration: a.IntrinsicDuration,
+				EntryPoint:        a.EntryPoint,
+				Duration:          a.Duration,
+				KeyID:             trimUUID(a.KeyID),
+				Hash:              strings.TrimSpace(a.Hash),
+			}
+			if strings.TrimSpace(a.EditRate) != "" {
+				if asset.EditRate, err = ParseEditRate(a.EditRate); err != nil {
+					return err
+				}
+			}
+			l.Assets = append(l.Assets, asset)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// trimUUID returns a UUID without its "urn:uuid:" prefix.
+func trimUUID(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= len(uuidURNPrefix) && strings.EqualFold(s[:len(uuidURNPrefix)], uuidURNPrefix) {
+		s = s[len(uuidURNPrefix):]
+	}
+	return strings.ToLower(s)
+}
+
+// Parse reads a Composition Playlist. ErrNotCompositionPlaylist is returned if the document is not a CPL.
+func Parse(r io.Reader) (*CompositionPlaylist, error) {
+	var x xmlCPL
+	if err := xml.NewDecoder(r).Decode(&x); err != nil {
+		return nil, err
+	}
+	if x.XMLName.Local != "CompositionPlaylist" {
+		return nil, ErrNotCompositionPlaylist
+	}
+
+	c := &CompositionPlaylist{
+		ID:               trimUUID(x.ID),
+		AnnotationText:   strings.TrimSpace(x.AnnotationText),
+		IssueDate:        strings.TrimSpace(x.IssueDate),
+		Issuer:           strings.TrimSpace(x.Issuer),
+		Creator:          strings.TrimSpace(x.Creator),
+		ContentTitleText: strings.TrimSpace(x.ContentTitleText),
+		ContentKind:      strings.TrimSpace(x.ContentKind),
+	}
+	switch x.XMLName.Space {
+	case NAMESPACE_INTEROP:
+		c.Standard = STANDARD_INTEROP
+	case NAMESPACE_SMPTE:
+		c.Standard = STANDARD_SMPTE
+	default:
+		return nil, ErrNotCompositionPlaylist
+	}
+
+	for _, r := range x.Reels {
+		c.Reels = append(c.Reels, Reel{
+			ID:     trimUUID(r.ID),
+			Assets: r.AssetList.Assets,
+		})
+	}
+	return c, nil
+}
+
+// ParseFile reads the Composition Playlist stored at path.
+func ParseFile(path string) (*CompositionPlaylist, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
@@ -0,0 +1,243 @@
+package cpl
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+const smpteCPL = `<?xml version="1.0" encoding="UTF-8"?>
+<CompositionPlaylist xmlns="http://www.smpte-ra.org/schemas/429-7/2006/CPL">
+  <Id>urn:uuid:AB9754D6-B1CF-4185-8554-4FC505670D7F</Id>
+  <AnnotationText>Andhra King Taluka</AnnotationText>
+  <IssueDate>2025-11-25T10:00:00+05:30</IssueDate>
+  <Issuer>ASV</Issuer>
+  <Creator>DCP-o-matic</Creator>
+  <ContentTitleText>AndhraKingThal_P2_FTR-2D-V3_S_TE-XX_IN-UA_51-Atmos_4K_ASV_20251125_ASV_SMPTE_VF</ContentTitleText>
+  <ContentKind scope="http://www.smpte-ra.org/schemas/429-7/2006/CPL#content-kind">feature</ContentKind>
+  <ReelList>
+    <Reel>
+      <Id>urn:uuid:00000000-0000-0000-0000-000000000001</Id>
+      <AssetList>
+        <MainPicture>
+          <Id>urn:uuid:00000000-0000-0000-0000-0000000000a1</Id>
+          <EditRate>24 1</EditRate>
+          <IntrinsicDuration>2400</IntrinsicDuration>
+          <EntryPoint>0</EntryPoint>
+          <Duration>2400</Duration>
+          <KeyId>urn:uuid:00000000-0000-0000-0000-0000000000f1</KeyId>
+          <FrameRate>24 1</FrameRate>
+          <ScreenAspectRatio>4096 1716</ScreenAspectRatio>
+        </MainPicture>
+        <MainSound>
+          <Id>urn:uuid:00000000-0000-0000-0000-0000000000b1</Id>
+          <EditRate>24 1</EditRate>
+          <IntrinsicDuration>2400</IntrinsicDuration>
+        </MainSound>
+        <axd:AuxData xmlns:axd="http://www.dolby.com/schemas/2012/AD">
+          <Id>urn:uuid:00000000-0000-0000-0000-0000000000c1</Id>
+          <EditRate>24 1</EditRate>
+          <IntrinsicDuration>2400</IntrinsicDuration>
+        </axd:AuxData>
+      </AssetList>
+    </Reel>
+    <Reel>
+      <Id>urn:uuid:00000000-0000-0000-0000-000000000002</Id>
+      <AssetList>
+        <MainPicture>
+          <Id>urn:uuid:00000000-0000-0000-0000-0000000000a2</Id>
+          <EditRate>24 1</EditRate>
+          <IntrinsicDuration>1500</IntrinsicDuration>
+          <EntryPoint>60</EntryPoint>
+        </MainPicture>
+        <MainSound>
+          <Id>urn:uuid:00000000-0000-0000-0000-0000000000b1</Id>
+          <EditRate>24 1</EditRate>
+          <IntrinsicDuration>1440</IntrinsicDuration>
+        </MainSound>
+      </AssetList>
+    </Reel>
+  </ReelList>
+</CompositionPlaylist>`
+
+const interopCPL = `<?xml version="1.0" encoding="UTF-8"?>
+<CompositionPlaylist xmlns="http://www.digicine.com/PROTO-ASDCP-CPL-20040511#">
+  <Id>urn:uuid:0f0e0d0c-0b0a-0908-0706-050403020100</Id>
+  <ContentTitleText>TestMovie_TLR-3D_F_EN-XX_IN-U_51_2K_STU_20240101_FAC_IOP_OV</ContentTitleText>
+  <ContentKind>trailer</ContentKind>
+  <ReelList>
+    <Reel>
+      <Id>urn:uuid:0f0e0d0c-0b0a-0908-0706-050403020101</Id>
+      <AssetList>
+        <msp-cpl:MainStereoscopicPicture xmlns:msp-cpl="http://www.digicine.com/schemas/437-Y/2007/Main-Stereo-Picture-CPL">
+          <Id>urn:uuid:0f0e0d0c-0b0a-0908-0706-0504030201a1</Id>
+          <EditRate>48 1</EditRate>
+          <IntrinsicDuration>4800</IntrinsicDuration>
+        </msp-cpl:MainStereoscopicPicture>
+      </AssetList>
+    </Reel>
+  </ReelList>
+</CompositionPlaylist>`
+
+func TestParse(t *testing.T) {
+	assert := assert.New(t)
+
+	c, err := Parse(strings.NewReader(smpteCPL))
+	assert.NoError(err)
+	assert.Equal(STANDARD_SMPTE, c.Standard)
+	assert.Equal("ab9754d6-b1cf-4185-8554-4fc505670d7f", c.ID)
+	assert.Equal("Andhra King Taluka", c.AnnotationText)
+	assert.Equal("AndhraKingThal_P2_FTR-2D-V3_S_TE-XX_IN-UA_51-Atmos_4K_ASV_20251125_ASV_SMPTE_VF", c.ContentTitleText)
+	assert.Equal("feature", c.ContentKind)
+	assert.Len(c.Reels, 2)
+	assert.Equal([]string{ASSET_MAIN_PICTURE, ASSET_MAIN_SOUND, ASSET_AUX_DATA},
+		[]string{c.Reels[0].Assets[0].Kind, c.Reels[0].Assets[1].Kind, c.Reels[0].Assets[2].Kind})
+	assert.Equal(EditRate{Numerator: 24, Denominator: 1}, c.EditRate())
+	assert.Equal(100*time.Second, c.Reels[0].Length())
+	assert.Equal(60*time.Second, c.Reels[1].Length())
+	assert.Equal(160*time.Second, c.Length())
+	assert.Equal([]string{
+		"00000000-0000-0000-0000-0000000000a1",
+		"00000000-0000-0000-0000-0000000000b1",
+		"00000000-0000-0000-0000-0000000000c1",
+		"00000000-0000-0000-0000-0000000000a2",
+	}, c.AssetIDs())
+	assert.True(c.Encrypted())
+	assert.False(c.Is3D())
+
+	c, err = Parse(strings.NewReader(interopCPL))
+	assert.NoError(err)
+	assert.Equal(STANDARD_INTEROP, c.Standard)
+	assert.Equal("trailer", c.ContentKind)
+	assert.True(c.Is3D())
+	assert.False(c.Encrypted())
+	assert.Equal(EditRate{Numerator: 48, Denominator: 1}, c.EditRate())
+	assert.Equal(100*time.Second, c.Length())
+
+	_, err = Parse(strings.NewReader(`<AssetMap xmlns="http://www.smpte-ra.org/schemas/429-9/2007/AM"></AssetMap>`))
+	assert.Equal(ErrNotCompositionPlaylist, err)
+
+	_, err = Parse(strings.NewReader(`<CompositionPlaylist xmlns="urn:other"></CompositionPlaylist>`))
+	assert.Equal(ErrNotCompositionPlaylist, err)
+
+	_, err = Parse(strings.NewReader(strings.Replace(smpteCPL, "<EditRate>24 1</EditRate>", "<EditRate>24</EditRate>", 1)))
+	assert.Error(err)
+}
+
+func TestParseEditRate(t *testing.T) {
+	var testCases = []struct {
+		desc     string
+		rate     string
+		expected EditRate
+		err      bool
+	}{
+		{desc: "integer rate", rate: "24 1", expected: EditRate{24, 1}},
+		{desc: "fractional rate", rate: " 24000  1001 ", expected: EditRate{24000, 1001}},
+		{desc: "missing denominator", rate: "24", err: true},
+		{desc: "zero denominator", rate: "24 0", err: true},
+		{desc: "not a number", rate: "twenty four", err: true},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			r, err := ParseEditRate(tC.rate)
+			if tC.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tC.expected, r)
+		})
+	}
+}
+
+func TestMapFile(t *testing.T) {
+	assert := assert.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mapped_cpls/ab9754d6-b1cf-4185-8554-4fc505670d7f" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{
+			"id": 476829,
+			"uuid": "ab9754d6-b1cf-4185-8554-4fc505670d7f",
+			"content_title_text": "AndhraKingThal_P2_FTR-2D-V3_S_TE-XX_IN-UA_51-Atmos_4K_ASV_20251125_ASV_SMPTE_VF",
+			"movie": {
+				"id": 99986,
+				"name": "Andhra King Taluka",
+				"uuid": "4f90f6fa-aab6-4717-a0a0-21d7e59dd1fd",
+				"part": {"id": 3, "uuid": "8928a455-2249-4152-94c8-a31249e05d7c", "name": "Part 2"}
+			}
+		}`))
+	}))
+	defer ts.Close()
+
+	mb := moviebuff.New(moviebuff.Config{HostURL: ts.URL, StaticToken: "token"})
+
+	dir, err := ioutil.TempDir("", "cpl")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "CPL.xml")
+	assert.NoError(ioutil.WriteFile(path, []byte(smpteCPL), 0644))
+
+	m, err := MapFile(context.Background(), mb, path)
+	assert.NoError(err)
+	assert.Equal("Andhra King Taluka", m.Movie().Name)
+	assert.Equal("Part 2", m.Part().Name)
+	assert.Equal("ab9754d6-b1cf-4185-8554-4fc505670d7f", m.CPL.ID)
+
+	unmapped := filepath.Join(dir, "unmapped.xml")
+	assert.NoError(ioutil.WriteFile(unmapped, []byte(interopCPL), 0644))
+	_, err = MapFile(context.Background(), mb, unmapped)
+	assert.Equal(moviebuff.ErrResourceDoesNotExist, err)
+
+	_, err = MapFile(context.Background(), mb, filepath.Join(dir, "missing.xml"))
+	assert.True(os.IsNotExist(err))
+}
+
+func TestMapping_Enrich(t *testing.T) {
+	assert := assert.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/languages":
+			w.Write([]byte(`[{"uuid": "te-uuid", "name": "Telugu", "iso639_1": "te"}]`))
+		case "/resources/movies/4f90f6fa-aab6-4717-a0a0-21d7e59dd1fd":
+			w.Write([]byte(`{"uuid": "4f90f6fa-aab6-4717-a0a0-21d7e59dd1fd", "type": "movie", "name": "Andhra King Taluka",
+				"language": "Telugu", "runningTime": 9000, "certifications": {"IN": "A"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	mb := moviebuff.New(moviebuff.Config{HostURL: ts.URL, StaticToken: "token"})
+
+	c, err := Parse(strings.NewReader(smpteCPL))
+	assert.NoError(err)
+	m := &Mapping{CPL: c, Mapped: &moviebuff.MappedCPL{
+		UUID:             c.ID,
+		ContentTitleText: c.ContentTitleText,
+		Movie: moviebuff.MappedCPLMovie{
+			UUID: "4f90f6fa-aab6-4717-a0a0-21d7e59dd1fd",
+			Part: &moviebuff.MappedCPLPart{Name: "Part 2"},
+		},
+	}}
+
+	e, err := m.Enrich(context.Background(), moviebuff.NewCPLChecker(mb))
+	assert.NoError(err)
+	assert.Equal("Andhra King Taluka", e.Movie.Name)
+	assert.Len(e.Mismatches, 1)
+	assert.Equal(moviebuff.CPL_FIELD_RATING, e.Mismatches[0].Field)
+}
@@ -0,0 +1,48 @@
+package cpl
+
+import (
+	"context"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+)
+
+// Mapping is a Composition Playlist and the Moviebuff movie it is mapped to.
+type Mapping struct {
+	CPL    *CompositionPlaylist
+	Mapped *moviebuff.MappedCPL
+}
+
+// Movie returns the Moviebuff movie the CPL is mapped to.
+func (m *Mapping) Movie() moviebuff.MappedCPLMovie {
+	return m.Mapped.Movie
+}
+
+// Part returns the part of the movie the CPL is mapped to, or nil if the CPL is not mapped to a part.
+func (m *Mapping) Part() *moviebuff.MappedCPLPart {
+	return m.Mapped.Movie.Part
+}
+
+// Map returns the mapping of the CPL, fetched with GetMappedCPL using the CPL's ID.
+// moviebuff.ErrResourceDoesNotExist is returned if the CPL is not mapped.
+func Map(ctx context.Context, mb moviebuff.Moviebuff, c *CompositionPlaylist) (*Mapping, error) {
+	mapped, err := mb.GetMappedCPL(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &Mapping{CPL: c, Mapped: mapped}, nil
+}
+
+// MapFile reads the Composition Playlist stored at path and returns its mapping. See Map.
+func MapFile(ctx context.Context, mb moviebuff.Moviebuff, path string) (*Mapping, error) {
+	c, err := ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Map(ctx, mb, c)
+}
+
+// Enrich fetches the movie the CPL is mapped to and cross-checks them, using the length of the CPL.
+// See moviebuff.CPLChecker.
+func (m *Mapping) Enrich(ctx context.Context, checker *moviebuff.CPLChecker) (*moviebuff.EnrichedCPL, error) {
+	return checker.Enrich(ctx, m.Mapped, m.CPL.Length())
+}
@@ -0,0 +1,127 @@
+package cpl
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+)
+
+// Status of the lookup of a CPL
+type Status string
+
+const (
+	// The CPL is mapped to a Moviebuff movie.
+	STATUS_MAPPED Status = "mapped"
+
+	// Moviebuff does not know the CPL.
+	STATUS_UNMAPPED Status = "unmapped"
+
+	// The lookup failed, it can be retried.
+	STATUS_FAILED Status = "failed"
+)
+
+// Result is the lookup of a CPL found on disk.
+type Result struct {
+	Path             string               `json:"path"`
+	CPLID            string               `json:"cplId"`
+	ContentTitleText string               `json:"contentTitleText"`
+	Status           Status               `json:"status"`
+	Mapped           *moviebuff.MappedCPL `json:"mapped,omitempty"`
+	Error            string               `json:"error,omitempty"`
+
+	// Err is the error of a failed lookup.
+	Err error `json:"-"`
+}
+
+// Report lists the lookups of the CPLs of a scan.
+type Report struct {
+	Results []Result      `json:"results"`
+	Invalid []InvalidFile `json:"invalid,omitempty"`
+}
+
+// ByStatus returns the results with the given status.
+func (r *Report) ByStatus(status Status) []Result {
+	var results []Result
+	for _, res := range r.Results {
+		if res.Status == status {
+			results = append(results, res)
+		}
+	}
+	return results
+}
+
+// Mapped returns the results of the CPLs mapped to a movie.
+func (r *Report) Mapped() []Result {
+	return r.ByStatus(STATUS_MAPPED)
+}
+
+// Unmapped returns the results of the CPLs Moviebuff does not know.
+func (r *Report) Unmapped() []Result {
+	return r.ByStatus(STATUS_UNMAPPED)
+}
+
+// Failed returns the results of the lookups which failed.
+func (r *Report) Failed() []Result {
+	return r.ByStatus(STATUS_FAILED)
+}
+
+// Movies returns the movies the CPLs are mapped to, without duplicates, in order of their first CPL.
+func (r *Report) Movies() []moviebuff.MappedCPLMovie {
+	seen := map[string]bool{}
+	var movies []moviebuff.MappedCPLMovie
+	for _, res := range r.Mapped() {
+		if !seen[res.Mapped.Movie.UUID] {
+			seen[res.Mapped.Movie.UUID] = true
+			movie := res.Mapped.Movie
+			movie.Part = nil
+			movies = append(movies, movie)
+		}
+	}
+	return movies
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// Columns of the CSV report
+var csvHeader = []string{
+	"path", "cpl_id", "content_title_text", "status",
+	"movie_id", "movie_uuid", "movie_name", "part_uuid", "part_name", "error",
+}
+
+// WriteCSV writes the results of the report as CSV with a header row. Invalid files are not written.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, res := range r.Results {
+		var movieID, movieUUID, movieName, partUUID, partName string
+		if res.Mapped != nil {
+			movieID = strconv.Itoa(res.Mapped.Movie.ID)
+			movieUUID = res.Mapped.Movie.UUID
+			movieName = res.Mapped.Movie.Name
+			if p := res.Mapped.Movie.Part; p != nil {
+				partUUID, partName = p.UUID, p.Name
+			}
+		}
+		record := []string{
+			res.Path, res.CPLID, res.ContentTitleText, string(res.Status),
+			movieID, movieUUID, movieName, partUUID, partName, res.Error,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
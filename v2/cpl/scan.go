@@ -0,0 +1,219 @@
+package cpl
+
+import (
+	"context"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+)
+
+// Names of the asset map of Interop and SMPTE packages
+const (
+	ASSETMAP_INTEROP = "ASSETMAP"
+	ASSETMAP_SMPTE   = "ASSETMAP.xml"
+)
+
+// File is a Composition Playlist found on disk.
+type File struct {
+	Path string
+	CPL  *CompositionPlaylist
+}
+
+// InvalidFile is a file which looked like a Composition Playlist but could not be read.
+type InvalidFile struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+// ScanOptions configures Scan.
+type ScanOptions struct {
+	// Concurrency is the maximum number of CPLs resolved at once. Defaults to 4.
+	Concurrency int
+}
+
+// XML representation of an asset map, matched by local name so that both namespaces are accepted.
+type xmlAssetMap struct {
+	XMLName xml.Name
+	Assets  []struct {
+		Paths []string `xml:"ChunkList>Chunk>Path"`
+	} `xml:"AssetList>Asset"`
+}
+
+// FindCPLs walks dir for Composition Playlists. The assets listed in ASSETMAP files are read,
+// along with any other XML file, so that CPLs are found whether or not they are packaged.
+//
+// A CPL present in several places is returned once, from the first path in lexical order.
+// Files which could not be read as XML, asset maps included, and assets listed in an asset map which are missing
+// or outside of dir, are returned as invalid, sorted by path.
+// Files which are valid XML but not CPLs are skipped.
+func FindCPLs(dir string) ([]File, []InvalidFile, error) {
+	candidates := map[string]bool{}
+	var invalid []InvalidFile
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		name := info.Name()
+		switch {
+		case name == ASSETMAP_INTEROP || name == ASSETMAP_SMPTE:
+			paths, outside, err := assetMapPaths(dir, path)
+			if err != nil {
+				invalid = append(invalid, InvalidFile{Path: path, Error: err.Error()})
+				return nil
+			}
+			invalid = append(invalid, outside...)
+			for _, p := range paths {
+				candidates[p] = true
+			}
+		case strings.EqualFold(filepath.Ext(name), ".xml"):
+			candidates[path] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	paths := make([]string, 0, len(candidates))
+	for p := range candidates {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var files []File
+	seen := map[string]bool{}
+	for _, p := range paths {
+		c, err := ParseFile(p)
+		switch {
+		case err == ErrNotCompositionPlaylist:
+		case err != nil:
+			invalid = append(invalid, InvalidFile{Path: p, Error: err.Error()})
+		case !seen[c.ID]:
+			seen[c.ID] = true
+			files = append(files, File{Path: p, CPL: c})
+		}
+	}
+	sort.SliceStable(invalid, func(i, j int) bool {
+		return invalid[i].Path < invalid[j].Path
+	})
+	return files, invalid, nil
+}
+
+// assetMapPaths returns the paths of the XML assets listed in an asset map, resolved against its directory.
+// Absolute paths and paths resolved outside of dir are returned as invalid.
+func assetMapPaths(dir, path string) ([]string, []InvalidFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	var am xmlAssetMap
+	if err := xml.NewDecoder(f).Decode(&am); err != nil {
+		return nil, nil, err
+	}
+
+	var paths []string
+	var outside []InvalidFile
+	for _, a := range am.Assets {
+		for _, p := range a.Paths {
+			p = strings.TrimPrefix(strings.TrimSpace(p), "file://")
+			if !strings.EqualFold(filepath.Ext(p), ".xml") {
+				continue
+			}
+			if strings.HasPrefix(p, "/") || filepath.IsAbs(p) {
+				outside = append(outside, InvalidFile{Path: p, Error: "asset path is absolute"})
+				continue
+			}
+			resolved := filepath.Join(filepath.Dir(path), filepath.FromSlash(p))
+			if !isWithin(dir, resolved) {
+				outside = append(outside, InvalidFile{Path: resolved, Error: "asset path is outside of " + dir})
+				continue
+			}
+			paths = append(paths, resolved)
+		}
+	}
+	return paths, outside, nil
+}
+
+// isWithin reports whether path is dir or inside it.
+func isWithin(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// Scan finds the Composition Playlists of dir and resolves them with GetMappedCPL. See FindCPLs and Resolve.
+func Scan(ctx context.Context, mb moviebuff.Moviebuff, dir string, opts ScanOptions) (*Report, error) {
+	files, invalid, err := FindCPLs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	report := Resolve(ctx, mb, files, opts)
+	report.Invalid = invalid
+	return report, nil
+}
+
+// Resolve looks the CPLs up concurrently with GetMappedCPL.
+// Results are returned in the order of the files.
+func Resolve(ctx context.Context, mb moviebuff.Moviebuff, files []File, opts ScanOptions) *Report {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	results := make([]Result, len(files))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = resolve(ctx, mb, files[i])
+			}
+		}()
+	}
+
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return &Report{Results: results}
+}
+
+func resolve(ctx context.Context, mb moviebuff.Moviebuff, f File) Result {
+	r := Result{
+		Path:             f.Path,
+		CPLID:            f.CPL.ID,
+		ContentTitleText: f.CPL.ContentTitleText,
+	}
+
+	m, err := Map(ctx, mb, f.CPL)
+	switch err {
+	case nil:
+		r.Status = STATUS_MAPPED
+		r.Mapped = m.Mapped
+	case moviebuff.ErrResourceDoesNotExist:
+		r.Status = STATUS_UNMAPPED
+	default:
+		r.Status = STATUS_FAILED
+		r.Err = err
+		r.Error = err.Error()
+	}
+	return r
+}
@@ -0,0 +1,157 @@
+package cpl
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+const assetMap = `<?xml version="1.0" encoding="UTF-8"?>
+<AssetMap xmlns="http://www.smpte-ra.org/schemas/429-9/2007/AM">
+  <AssetList>
+    <Asset>
+      <Id>urn:uuid:00000000-0000-0000-0000-0000000000e1</Id>
+      <PackingList>true</PackingList>
+      <ChunkList><Chunk><Path>PKL_1.xml</Path></Chunk></ChunkList>
+    </Asset>
+    <Asset>
+      <Id>urn:uuid:ab9754d6-b1cf-4185-8554-4fc505670d7f</Id>
+      <ChunkList><Chunk><Path>CPL_1.xml</Path></Chunk></ChunkList>
+    </Asset>
+    <Asset>
+      <Id>urn:uuid:00000000-0000-0000-0000-0000000000a1</Id>
+      <ChunkList><Chunk><Path>picture.mxf</Path></Chunk></ChunkList>
+    </Asset>
+    <Asset>
+      <Id>urn:uuid:00000000-0000-0000-0000-0000000000e2</Id>
+      <ChunkList><Chunk><Path>missing.xml</Path></Chunk></ChunkList>
+    </Asset>
+  </AssetList>
+</AssetMap>`
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "dcps")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	failingCPL := strings.Replace(interopCPL, "0f0e0d0c-0b0a-0908-0706-050403020100", "0f0e0d0c-0b0a-0908-0706-0504030201ff", 1)
+	writeFiles(t, dir, map[string]string{
+		"pkg1/ASSETMAP.xml":   assetMap,
+		"pkg1/PKL_1.xml":      `<PackingList xmlns="http://www.smpte-ra.org/schemas/429-8/2007/PKL"></PackingList>`,
+		"pkg1/CPL_1.xml":      smpteCPL,
+		"pkg1/picture.mxf":    "not xml",
+		"pkg2/cpl.xml":        interopCPL,
+		"pkg3/copy.xml":       smpteCPL,
+		"pkg3/notes.txt":      "ignored",
+		"pkg4/CPL_FAILED.XML": failingCPL,
+		"broken.xml":          "<CompositionPlaylist",
+		"pkg5/ASSETMAP":       "<AssetMap",
+		"pkg6/ASSETMAP.xml": `<AssetMap><AssetList>
+			<Asset><ChunkList><Chunk><Path>../pkg2/cpl.xml</Path></Chunk></ChunkList></Asset>
+			<Asset><ChunkList><Chunk><Path>../../outside.xml</Path></Chunk></ChunkList></Asset>
+			<Asset><ChunkList><Chunk><Path>/etc/cpl.xml</Path></Chunk></ChunkList></Asset>
+		</AssetList></AssetMap>`,
+	})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mapped_cpls/ab9754d6-b1cf-4185-8554-4fc505670d7f":
+			w.Write([]byte(`{
+				"id": 476829,
+				"uuid": "ab9754d6-b1cf-4185-8554-4fc505670d7f",
+				"content_title_text": "AndhraKingThal_P2_FTR-2D-V3_S_TE-XX_IN-UA_51-Atmos_4K_ASV_20251125_ASV_SMPTE_VF",
+				"movie": {
+					"id": 99986,
+					"name": "Andhra King Taluka",
+					"uuid": "4f90f6fa-aab6-4717-a0a0-21d7e59dd1fd",
+					"part": {"id": 3, "uuid": "8928a455-2249-4152-94c8-a31249e05d7c", "name": "Part 2"}
+				}
+			}`))
+		case "/mapped_cpls/0f0e0d0c-0b0a-0908-0706-0504030201ff":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	mb := moviebuff.New(moviebuff.Config{HostURL: ts.URL, StaticToken: "token"})
+
+	report, err := Scan(context.Background(), mb, dir, ScanOptions{Concurrency: 2})
+	assert.NoError(err)
+
+	var paths []string
+	for _, r := range report.Results {
+		rel, _ := filepath.Rel(dir, r.Path)
+		paths = append(paths, filepath.ToSlash(rel)+" "+string(r.Status))
+	}
+	assert.Equal([]string{
+		"pkg1/CPL_1.xml mapped",
+		"pkg2/cpl.xml unmapped",
+		"pkg4/CPL_FAILED.XML failed",
+	}, paths)
+
+	var invalid []string
+	for _, f := range report.Invalid {
+		if strings.HasPrefix(f.Path, filepath.Dir(dir)) {
+			rel, _ := filepath.Rel(dir, f.Path)
+			invalid = append(invalid, filepath.ToSlash(rel))
+			continue
+		}
+		invalid = append(invalid, filepath.ToSlash(f.Path))
+	}
+	assert.Equal([]string{"/etc/cpl.xml", "broken.xml", "pkg1/missing.xml", "pkg5/ASSETMAP", "../outside.xml"}, invalid)
+
+	assert.Len(report.Mapped(), 1)
+	assert.Len(report.Unmapped(), 1)
+	assert.Len(report.Failed(), 1)
+	assert.Equal(moviebuff.ErrResponseNotReceived, report.Failed()[0].Err)
+	assert.Equal("Andhra King Taluka", report.Movies()[0].Name)
+	assert.Nil(report.Movies()[0].Part)
+
+	var buf bytes.Buffer
+	assert.NoError(report.WriteCSV(&buf))
+	records, err := csv.NewReader(&buf).ReadAll()
+	assert.NoError(err)
+	assert.Len(records, 4)
+	assert.Equal(csvHeader, records[0])
+	assert.Equal([]string{"ab9754d6-b1cf-4185-8554-4fc505670d7f", "mapped", "99986", "Andhra King Taluka", "Part 2"},
+		[]string{records[1][1], records[1][3], records[1][4], records[1][6], records[1][8]})
+	assert.Equal(moviebuff.ErrResponseNotReceived.Error(), records[3][9])
+
+	buf.Reset()
+	assert.NoError(report.WriteJSON(&buf))
+	var decoded Report
+	assert.NoError(json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(STATUS_MAPPED, decoded.Results[0].Status)
+	assert.Equal("Part 2", decoded.Results[0].Mapped.Movie.Part.Name)
+	assert.Len(decoded.Invalid, 5)
+
+	_, err = Scan(context.Background(), mb, filepath.Join(dir, "missing"), ScanOptions{})
+	assert.True(os.IsNotExist(err))
+}
@@ -0,0 +1,212 @@
+package moviebuff
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Fields of a mapped CPL cross-checked against its movie
+const (
+	CPL_FIELD_CONTENT_TITLE = "contentTitle"
+	CPL_FIELD_LANGUAGE      = "language"
+	CPL_FIELD_RATING        = "rating"
+	CPL_FIELD_RUNNING_TIME  = "runningTime"
+)
+
+// DEFAULT_RUNNING_TIME_TOLERANCE is the difference allowed between the length of a CPL and the running time of its movie.
+const DEFAULT_RUNNING_TIME_TOLERANCE = 5 * time.Minute
+
+// CPLMismatch is a field of a mapped CPL which does not agree with its movie.
+type CPLMismatch struct {
+	Field string `json:"field"`
+
+	// CPL and Movie are the values of the field in the CPL and in the movie. Either is empty if it is missing.
+	CPL   string `json:"cpl"`
+	Movie string `json:"movie"`
+
+	Message string `json:"message"`
+}
+
+// EnrichedCPL is a mapped CPL with its full movie and the mismatches between them.
+type EnrichedCPL struct {
+	MappedCPL *MappedCPL
+
+	Movie *Movie
+
+	// ContentTitle is the parsed content title text of the CPL. It holds the fields that could be parsed
+	// if the content title text is not valid.
+	ContentTitle *ContentTitle
+
+	Mismatches []CPLMismatch
+}
+
+// CPLChecker cross-checks the content title text of mapped CPLs against their movies for quality control.
+type CPLChecker struct {
+	// RunningTimeTolerance is the difference allowed between the length of a CPL and the running time of its movie.
+	// Defaults to DEFAULT_RUNNING_TIME_TOLERANCE.
+	RunningTimeTolerance time.Duration
+
+	mb        Moviebuff
+	languages *LanguageCatalog
+}
+
+// NewCPLChecker returns a checker fetching movies and languages from mb.
+func NewCPLChecker(mb Moviebuff) *CPLChecker {
+	return &CPLChecker{
+		RunningTimeTolerance: DEFAULT_RUNNING_TIME_TOLERANCE,
+		mb:                   mb,
+		languages:            NewLanguageCatalog(mb),
+	}
+}
+
+// Enrich fetches the movie of a mapped CPL and cross-checks the CPL against it. See Check.
+func (c *CPLChecker) Enrich(ctx context.Context, mapped *MappedCPL, length time.Duration) (*EnrichedCPL, error) {
+	m, err := c.mb.GetMovie(ctx, mapped.Movie.UUID)
+	if err != nil {
+		return nil, err
+	}
+	return c.Check(ctx, mapped, m, length)
+}
+
+// Check cross-checks the content title text of a mapped CPL against its movie:
+//
+//   - the audio language against the language of the movie, both resolved with the language catalog,
+//   - the rating against the certification of the movie in the territory of the CPL,
+//   - the length of the CPL against the running time of the movie, unless length is zero
+//     or the CPL is mapped to a part of the movie.
+//
+// A content title text which is not valid is reported as a mismatch, and the fields that could be parsed are still checked.
+func (c *CPLChecker) Check(ctx context.Context, mapped *MappedCPL, m *Movie, length time.Duration) (*EnrichedCPL, error) {
+	e := &EnrichedCPL{
+		MappedCPL: mapped,
+		Movie:     m,
+	}
+
+	title, err := mapped.ParseContentTitle()
+	if err != nil {
+		e.Mismatches = append(e.Mismatches, CPLMismatch{
+			Field:   CPL_FIELD_CONTENT_TITLE,
+			CPL:     mapped.ContentTitleText,
+			Message: err.Error(),
+		})
+	}
+	e.ContentTitle = title
+
+	mismatch, err := c.checkLanguage(ctx, title, m)
+	if err != nil {
+		return nil, err
+	}
+	if mismatch != nil {
+		e.Mismatches = append(e.Mismatches, *mismatch)
+	}
+
+	if mismatch := checkRating(title, m); mismatch != nil {
+		e.Mismatches = append(e.Mismatches, *mismatch)
+	}
+
+	if mapped.Movie.Part == nil {
+		if mismatch := c.checkRunningTime(length, m); mismatch != nil {
+			e.Mismatches = append(e.Mismatches, *mismatch)
+		}
+	}
+	return e, nil
+}
+
+// checkLanguage compares the audio language of the CPL with the language of the movie.
+// Languages unknown to the catalog are compared as written.
+func (c *CPLChecker) checkLanguage(ctx context.Context, title *ContentTitle, m *Movie) (*CPLMismatch, error) {
+	if title.AudioLanguage == "" || m.Language == "" {
+		return nil, nil
+	}
+
+	mismatch := &CPLMismatch{
+		Field:   CPL_FIELD_LANGUAGE,
+		CPL:     title.AudioLanguage,
+		Movie:   m.Language,
+		Message: fmt.Sprintf("audio language %s is not the movie's language %s", title.AudioLanguage, m.Language),
+	}
+
+	cplLanguage, err := c.languages.Resolve(ctx, title.AudioLanguage)
+	if err != nil && err != ErrResourceDoesNotExist {
+		return nil, err
+	}
+	movieLanguage, err := c.languages.MovieLanguage(ctx, m)
+	if err != nil && err != ErrResourceDoesNotExist {
+		return nil, err
+	}
+
+	if cplLanguage == nil || movieLanguage == nil {
+		if strings.EqualFold(title.AudioLanguage, strings.TrimSpace(m.Language)) {
+			return nil, nil
+		}
+		return mismatch, nil
+	}
+	if cplLanguage.UUID == movieLanguage.UUID {
+		return nil, nil
+	}
+	return mismatch, nil
+}
+
+// checkRating compares the rating of the CPL with the certification of the movie in the CPL's territory.
+func checkRating(title *ContentTitle, m *Movie) *CPLMismatch {
+	if title.Rating == "" || title.Territory == "" {
+		return nil
+	}
+
+	certification, ok := m.Certifications[title.Territory]
+	if !ok || certification == "" {
+		return &CPLMismatch{
+			Field:   CPL_FIELD_RATING,
+			CPL:     title.Rating,
+			Message: fmt.Sprintf("movie has no certification in %s", title.Territory),
+		}
+	}
+	if normalizeRating(certification) == normalizeRating(title.Rating) {
+		return nil
+	}
+	return &CPLMismatch{
+		Field:   CPL_FIELD_RATING,
+		CPL:     title.Rating,
+		Movie:   certification,
+		Message: fmt.Sprintf("rating %s is not the movie's certification %s in %s", title.Rating, certification, title.Territory),
+	}
+}
+
+// normalizeRating returns a rating upper cased without punctuation or spaces, so that "U/A" and "UA" are equal.
+func normalizeRating(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '+' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// checkRunningTime compares the length of the CPL with the running time of the movie.
+func (c *CPLChecker) checkRunningTime(length time.Duration, m *Movie) *CPLMismatch {
+	runningTime := m.GetRunningTime()
+	if length <= 0 || runningTime <= 0 {
+		return nil
+	}
+
+	tolerance := c.RunningTimeTolerance
+	if tolerance <= 0 {
+		tolerance = DEFAULT_RUNNING_TIME_TOLERANCE
+	}
+	diff := length - runningTime
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff <= tolerance {
+		return nil
+	}
+	return &CPLMismatch{
+		Field:   CPL_FIELD_RUNNING_TIME,
+		CPL:     FormatRunningTime(length),
+		Movie:   FormatRunningTime(runningTime),
+		Message: fmt.Sprintf("length %s differs from the movie's running time %s by more than %s", FormatRunningTime(length), FormatRunningTime(runningTime), FormatRunningTime(tolerance)),
+	}
+}
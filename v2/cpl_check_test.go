@@ -0,0 +1,107 @@
+package moviebuff
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCPLChecker(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter,
+		r *http.Request) {
+		switch r.URL.Path {
+		case "/languages":
+			w.Write([]byte(`[
+				{"uuid": "te-uuid", "name": "Telugu", "iso639_1": "te", "iso639_2": "tel", "iana": "te"},
+				{"uuid": "hi-uuid", "name": "Hindi", "iso639_1": "hi", "iso639_2": "hin", "iana": "hi"}
+			]`))
+		case "/resources/movies/andhra-king-taluka":
+			w.Write([]byte(`{
+				"uuid": "andhra-king-taluka",
+				"type": "movie",
+				"name": "Andhra King Taluka",
+				"language": "Telugu",
+				"runningTime": 9000,
+				"certifications": {"IN": "U/A"}
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	checker := NewCPLChecker(New(Config{
+		HostURL:     ts.URL,
+		StaticToken: "staticToken",
+	}))
+
+	var testCases = []struct {
+		desc     string
+		text     string
+		part     *MappedCPLPart
+		length   time.Duration
+		expected []string
+	}{
+		{
+			desc:   "matching cpl",
+			text:   "AndhraKingThal_FTR-2D_S_TE-XX_IN-UA_51-Atmos_4K_ASV_20251125_ASV_SMPTE_OV",
+			length: 2*time.Hour + 28*time.Minute,
+		},
+		{
+			desc:     "mismatching cpl",
+			text:     "AndhraKingThal_FTR-2D_S_HI-XX_IN-A_51_4K_ASV_20251125_ASV_SMPTE_VF",
+			length:   2 * time.Hour,
+			expected: []string{CPL_FIELD_LANGUAGE, CPL_FIELD_RATING, CPL_FIELD_RUNNING_TIME},
+		},
+		{
+			desc:     "part of the movie without certification in the territory",
+			text:     "AndhraKingThal_P2_FTR_S_TE-XX_AE-15+_51_4K_ASV_20251125",
+			part:     &MappedCPLPart{Name: "Part 2"},
+			length:   time.Hour,
+			expected: []string{CPL_FIELD_RATING},
+		},
+		{
+			desc:     "invalid content title",
+			text:     "AndhraKingThal_FTR_S_TE-XX_IN-UA_51_4K_ASV_2025",
+			expected: []string{CPL_FIELD_CONTENT_TITLE},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			mapped := &MappedCPL{
+				ContentTitleText: tC.text,
+				Movie:            MappedCPLMovie{UUID: "andhra-king-taluka", Part: tC.part},
+			}
+			e, err := checker.Enrich(context.Background(), mapped, tC.length)
+			assert.NoError(t, err)
+			assert.Equal(t, "Andhra King Taluka", e.Movie.Name)
+			assert.Equal(t, "AndhraKingThal", e.ContentTitle.Title[:14])
+
+			var fields []string
+			for _, m := range e.Mismatches {
+				fields = append(fields, m.Field)
+			}
+			assert.Equal(t, tC.expected, fields)
+		})
+	}
+
+	e, err := checker.Enrich(context.Background(), &MappedCPL{
+		ContentTitleText: "AndhraKingThal_FTR_S_HI-XX_IN-A_51_4K_ASV_20251125",
+		Movie:            MappedCPLMovie{UUID: "andhra-king-taluka"},
+	}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, CPLMismatch{
+		Field:   CPL_FIELD_RATING,
+		CPL:     "A",
+		Movie:   "U/A",
+		Message: "rating A is not the movie's certification U/A in IN",
+	}, e.Mismatches[1])
+
+	_, err = checker.Enrich(context.Background(), &MappedCPL{Movie: MappedCPLMovie{UUID: "unknown"}}, 0)
+	assert.Equal(t, ErrResourceDoesNotExist, err)
+}
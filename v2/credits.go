@@ -0,0 +1,190 @@
+package moviebuff
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Department name used for the cast of a movie when the API does not provide one.
+const DEPARTMENT_CAST = "Cast"
+
+// roleAliases maps normalised alternate role names to the role name used by Moviebuff.
+// Roles are compared after being normalised with NormalizeRole, so both keys and values must be normalised.
+var roleAliases = map[string]string{
+	"directed by":               "director",
+	"film director":             "director",
+	"music composer":            "music director",
+	"composer":                  "music director",
+	"music":                     "music director",
+	"original music":            "music director",
+	"background score":          "background music",
+	"bgm":                       "background music",
+	"cinematographer":           "director of photography",
+	"cinematography":            "director of photography",
+	"dop":                       "director of photography",
+	"editor":                    "film editor",
+	"editing":                   "film editor",
+	"written by":                "writer",
+	"screenwriter":              "screenplay",
+	"lyricist":                  "lyrics",
+	"produced by":               "producer",
+	"choreographer":             "choreography",
+	"dance choreographer":       "choreography",
+	"stunt choreographer":       "action director",
+	"stunts":                    "action director",
+	"art director":              "art direction",
+	"production designer":       "production design",
+	"costume designer":          "costume design",
+	"playback singer":           "singer",
+	"sound designer":            "sound design",
+	"visual effects":            "vfx",
+	"visual effects supervisor": "vfx supervisor",
+}
+
+// RoleAliases returns a copy of the normalised alternate role names resolved by NormalizeRole,
+// mapped against the role name used by Moviebuff.
+func RoleAliases() map[string]string {
+	aliases := make(map[string]string, len(roleAliases))
+	for alias, role := range roleAliases {
+		aliases[alias] = role
+	}
+	return aliases
+}
+
+// NormalizeRole returns the canonical form of a role or department name.
+// Letters are lower cased, punctuation is dropped, spaces are collapsed and aliases from RoleAliases are resolved,
+// so that "Music Composer" and "music-director" are both normalised to "music director".
+func NormalizeRole(role string) string {
+	normalized := normalizeName(role)
+	if alias, ok := roleAliases[normalized]; ok {
+		return alias
+	}
+	return normalized
+}
+
+// normalizeName lower cases s, drops punctuation and collapses spaces.
+func normalizeName(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// CreditQuery selects credits of a movie. Zero values of the fields are ignored.
+type CreditQuery struct {
+	// Department of the credit like "Direction" or "Cast". Compared ignoring case and punctuation.
+	Department string
+
+	// Role of the credit like "Director" or "Music Director". Compared using NormalizeRole.
+	Role string
+
+	// PrimaryOnly selects only the credits marked primary.
+	PrimaryOnly bool
+
+	// Unique keeps only the first credit of a person credited in several roles.
+	Unique bool
+}
+
+// Match reports whether the credit is selected by the query, ignoring Unique.
+func (q CreditQuery) Match(c Credit) bool {
+	if q.PrimaryOnly && !c.Primary {
+		return false
+	}
+	if q.Department != "" && normalizeName(q.Department) != normalizeName(c.Department) {
+		return false
+	}
+	if q.Role != "" && NormalizeRole(q.Role) != NormalizeRole(c.Role) {
+		return false
+	}
+	return true
+}
+
+// GetCredits returns the cast in billing order followed by the crew in department order.
+// Department of each credit is filled from the group it was listed in when the API omits it.
+func (m *Movie) GetCredits() []Credit {
+	credits := make([]Credit, 0, len(m.Cast))
+	for _, c := range m.Cast {
+		if c.Department == "" {
+			c.Department = DEPARTMENT_CAST
+		}
+		credits = append(credits, c)
+	}
+
+	for _, department := range m.Crew {
+		for _, c := range department.Roles {
+			if c.Department == "" {
+				c.Department = department.Department
+			}
+			credits = append(credits, c)
+		}
+	}
+	return credits
+}
+
+// QueryCredits returns the credits selected by q, in the order of GetCredits.
+func (m *Movie) QueryCredits(q CreditQuery) []Credit {
+	var credits []Credit
+	for _, c := range m.GetCredits() {
+		if q.Match(c) {
+			credits = append(credits, c)
+		}
+	}
+
+	if q.Unique {
+		credits = UniqueCredits(credits)
+	}
+	return credits
+}
+
+// GetCreditsByDepartment returns the credits of the given department like "Direction".
+func (m *Movie) GetCreditsByDepartment(department string) []Credit {
+	return m.QueryCredits(CreditQuery{Department: department})
+}
+
+// GetCreditsByRole returns the credits of the given role like "Music Director".
+func (m *Movie) GetCreditsByRole(role string) []Credit {
+	return m.QueryCredits(CreditQuery{Role: role})
+}
+
+// GetDirectors returns the directors of the movie.
+func (m *Movie) GetDirectors() []Credit {
+	return m.QueryCredits(CreditQuery{Role: "director", Unique: true})
+}
+
+// GetMusicDirectors returns the music directors of the movie.
+func (m *Movie) GetMusicDirectors() []Credit {
+	return m.QueryCredits(CreditQuery{Role: "music director", Unique: true})
+}
+
+// GetPrimaryCast returns the primary cast of the movie in billing order.
+// Department is filled like GetCredits does when the API omits it.
+func (m *Movie) GetPrimaryCast() []Credit {
+	var cast []Credit
+	for _, c := range m.Cast {
+		if c.Primary {
+			if c.Department == "" {
+				c.Department = DEPARTMENT_CAST
+			}
+			cast = append(cast, c)
+		}
+	}
+	return UniqueCredits(cast)
+}
+
+// UniqueCredits returns credits keeping only the first credit of each person or entity.
+// Credits are identified by their UUID, or by their name when the UUID is not available.
+func UniqueCredits(credits []Credit) []Credit {
+	seen := make(map[string]bool, len(credits))
+	var unique []Credit
+	for _, c := range credits {
+		key := c.UUID
+		if key == "" {
+			key = "name:" + c.Name
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, c)
+	}
+	return unique
+}
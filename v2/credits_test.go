@@ -0,0 +1,110 @@
+package moviebuff
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const creditsMovie = `{
+	"cast": [
+		{"name": "Ranveer Singh", "uuid": "p1", "role": "Actor", "primary": true, "character": "Alauddin Khilji"},
+		{"name": "Deepika Padukone", "uuid": "p2", "role": "Actor", "primary": true, "character": "Padmavati"},
+		{"name": "Aditi Rao Hydari", "uuid": "p3", "role": "Actor", "primary": false, "character": "Mehrunisa"}
+	],
+	"crew": [
+		{
+			"department": "Direction",
+			"roles": [
+				{"name": "Sanjay Leela Bhansali", "uuid": "p4", "role": "Director", "primary": true},
+				{"name": "Assistant", "uuid": "p5", "role": "Assistant Director"}
+			]
+		},
+		{
+			"department": "Music",
+			"roles": [
+				{"name": "Sanjay Leela Bhansali", "uuid": "p4", "role": "Music Composer", "department": "Music"},
+				{"name": "Sanchit Balhara", "uuid": "p6", "role": "Background Score", "department": "Music"}
+			]
+		}
+	]
+}`
+
+func creditNames(credits []Credit) []string {
+	var n []string
+	for _, c := range credits {
+		n = append(n, c.Name)
+	}
+	return n
+}
+
+func TestMovie_QueryCredits(t *testing.T) {
+	m := new(Movie)
+	assert.NoError(t, json.Unmarshal([]byte(creditsMovie), m))
+
+	var testCases = []struct {
+		desc          string
+		query         CreditQuery
+		expectedNames []string
+	}{
+		{
+			desc:          "all credits",
+			query:         CreditQuery{},
+			expectedNames: []string{"Ranveer Singh", "Deepika Padukone", "Aditi Rao Hydari", "Sanjay Leela Bhansali", "Assistant", "Sanjay Leela Bhansali", "Sanchit Balhara"},
+		}, {
+			desc:          "unique credits",
+			query:         CreditQuery{Unique: true},
+			expectedNames: []string{"Ranveer Singh", "Deepika Padukone", "Aditi Rao Hydari", "Sanjay Leela Bhansali", "Assistant", "Sanchit Balhara"},
+		}, {
+			desc:          "by department filled from the group",
+			query:         CreditQuery{Department: "direction"},
+			expectedNames: []string{"Sanjay Leela Bhansali", "Assistant"},
+		}, {
+			desc:          "cast department",
+			query:         CreditQuery{Department: "CAST", PrimaryOnly: true},
+			expectedNames: []string{"Ranveer Singh", "Deepika Padukone"},
+		}, {
+			desc:          "by role alias",
+			query:         CreditQuery{Role: "music-director"},
+			expectedNames: []string{"Sanjay Leela Bhansali"},
+		}, {
+			desc:          "by role alias on both sides",
+			query:         CreditQuery{Role: "BGM"},
+			expectedNames: []string{"Sanchit Balhara"},
+		}, {
+			desc:  "no match",
+			query: CreditQuery{Role: "Producer"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedNames, creditNames(m.QueryCredits(testCase.query)))
+		})
+	}
+}
+
+func TestMovie_CreditHelpers(t *testing.T) {
+	assert := assert.New(t)
+
+	m := new(Movie)
+	assert.NoError(json.Unmarshal([]byte(creditsMovie), m))
+
+	assert.Equal([]string{"Sanjay Leela Bhansali"}, creditNames(m.GetDirectors()))
+	assert.Equal([]string{"Sanjay Leela Bhansali"}, creditNames(m.GetMusicDirectors()))
+	assert.Equal([]string{"Ranveer Singh", "Deepika Padukone"}, creditNames(m.GetPrimaryCast()))
+	assert.Equal("Music", m.GetCreditsByRole("composer")[0].Department)
+	assert.Equal(DEPARTMENT_CAST, m.GetCredits()[0].Department)
+	assert.Equal(DEPARTMENT_CAST, m.GetPrimaryCast()[0].Department)
+}
+
+func TestRoleAliases(t *testing.T) {
+	assert := assert.New(t)
+
+	aliases := RoleAliases()
+	assert.Equal("director", aliases["film director"])
+
+	aliases["film director"] = "producer"
+	assert.Equal("director", NormalizeRole("Film Director"))
+}
@@ -0,0 +1,386 @@
+package moviebuff
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Content types of the Digital Cinema Naming Convention
+const (
+	CONTENT_TYPE_FEATURE        = "FTR"
+	CONTENT_TYPE_TRAILER        = "TLR"
+	CONTENT_TYPE_TEASER         = "TSR"
+	CONTENT_TYPE_PROMO          = "PRO"
+	CONTENT_TYPE_TEST           = "TST"
+	CONTENT_TYPE_RATING         = "RTG"
+	CONTENT_TYPE_ADVERTISEMENT  = "ADV"
+	CONTENT_TYPE_SHORT          = "SHR"
+	CONTENT_TYPE_TRANSITIONAL   = "XSN"
+	CONTENT_TYPE_PUBLIC_SERVICE = "PSA"
+	CONTENT_TYPE_POLICY         = "POL"
+	CONTENT_TYPE_EPISODE        = "EPS"
+	CONTENT_TYPE_HIGHLIGHTS     = "HLT"
+	CONTENT_TYPE_EVENT          = "EVT"
+)
+
+// Packaging standards and package types of the Digital Cinema Naming Convention
+const (
+	DCP_STANDARD_SMPTE   = "SMPTE"
+	DCP_STANDARD_INTEROP = "IOP"
+
+	PACKAGE_TYPE_ORIGINAL_VERSION = "OV"
+	PACKAGE_TYPE_VERSION_FILE     = "VF"
+)
+
+// Subtitle language of content titles without subtitles
+const CONTENT_TITLE_NO_SUBTITLES = "XX"
+
+const (
+	contentTitleDateLayout         = "20060102"
+	contentTitleSeparator          = "_"
+	contentTitleModifiersSeparator = "-"
+)
+
+var contentTypes = map[string]bool{
+	CONTENT_TYPE_FEATURE:        true,
+	CONTENT_TYPE_TRAILER:        true,
+	CONTENT_TYPE_TEASER:         true,
+	CONTENT_TYPE_PROMO:          true,
+	CONTENT_TYPE_TEST:           true,
+	CONTENT_TYPE_RATING:         true,
+	CONTENT_TYPE_ADVERTISEMENT:  true,
+	CONTENT_TYPE_SHORT:          true,
+	CONTENT_TYPE_TRANSITIONAL:   true,
+	CONTENT_TYPE_PUBLIC_SERVICE: true,
+	CONTENT_TYPE_POLICY:         true,
+	CONTENT_TYPE_EPISODE:        true,
+	CONTENT_TYPE_HIGHLIGHTS:     true,
+	CONTENT_TYPE_EVENT:          true,
+}
+
+var (
+	dcncTitlePattern       = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+	dcncVersionPattern     = regexp.MustCompile(`^[Vv]?(\d+)$`)
+	dcncAspectPattern      = regexp.MustCompile(`^(F|S|C)(-(\d{2,3}))?$`)
+	dcncLanguagePattern    = regexp.MustCompile(`^[A-Za-z]{2,3}$`)
+	dcncTerritoryPattern   = regexp.MustCompile(`^[A-Z]{2,3}$`)
+	dcncAudioPattern       = regexp.MustCompile(`^(\d{2}|MOS)$`)
+	dcncResolutionPattern  = regexp.MustCompile(`^\d+K$`)
+	dcncCodePattern        = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+	dcncRatingPattern      = regexp.MustCompile(`^[A-Za-z0-9+-]+$`)
+	dcncStandardPattern    = regexp.MustCompile(`^(SMPTE|IOP)(-.+)?$`)
+	dcncPackageTypePattern = regexp.MustCompile(`^(OV|VF)(-?\d+)?$`)
+)
+
+// ContentTitle is a content title text following the Digital Cinema Naming Convention, like
+// "MOVIE_FTR-1_F_EN-XX_IN-UA_51_2K_STU_20240101_FAC_SMPTE_OV".
+//
+// Fields are kept as written, so that String returns the parsed content title text unchanged.
+type ContentTitle struct {
+	// Title of the content. It includes any qualifier written before the content type, like "AndhraKingThal_P2".
+	Title string
+
+	// ContentType is a content type code like "FTR".
+	ContentType string
+
+	// ContentTypeModifiers follow the content type, like "1" in "FTR-1" or "2D" and "V3" in "FTR-2D-V3".
+	ContentTypeModifiers []string
+
+	// AspectRatio is "F" for flat, "S" for scope or "C" for full container.
+	AspectRatio string
+
+	// ImageAspectRatio is the optional aspect ratio of the image, like "178" in "F-178".
+	ImageAspectRatio string
+
+	// AudioLanguage and SubtitleLanguage are language codes like "EN". SubtitleLanguage is "XX" when there are no subtitles.
+	AudioLanguage    string
+	SubtitleLanguage string
+
+	// LanguageModifiers follow the languages, like "CCAP" in "EN-XX-CCAP".
+	LanguageModifiers []string
+
+	// Territory is a country code like "IN" and Rating the rating of the content in the territory, like "UA".
+	Territory string
+	Rating    string
+
+	// AudioFormat is the channel configuration, like "51", and AudioModifiers follow it, like "Atmos" in "51-Atmos".
+	AudioFormat    string
+	AudioModifiers []string
+
+	// Resolution is like "2K" or "4K".
+	Resolution string
+
+	// Studio is the code of the studio.
+	Studio string
+
+	// Date the package was created.
+	Date time.Time
+
+	// Facility is the optional code of the facility that created the package.
+	Facility string
+
+	// Standard is the optional packaging standard, "SMPTE" or "IOP", and StandardModifiers follow it.
+	Standard          string
+	StandardModifiers []string
+
+	// PackageType is the optional package type, "OV" for original version or "VF" for version file.
+	PackageType string
+}
+
+// ContentTitleError is a field of a content title text which is not valid.
+type ContentTitleError struct {
+	Field  string
+	Value  string
+	Reason string
+}
+
+func (e *ContentTitleError) Error() string {
+	return fmt.Sprintf("%v: %s %q %s", ErrInvalidContentTitle, e.Field, e.Value, e.Reason)
+}
+
+func (e *ContentTitleError) Unwrap() error {
+	return ErrInvalidContentTitle
+}
+
+// ContentTitleErrors lists the invalid fields of a content title text.
+type ContentTitleErrors []*ContentTitleError
+
+func (e ContentTitleErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is reports ContentTitleErrors as ErrInvalidContentTitle.
+func (e ContentTitleErrors) Is(target error) bool {
+	return target == ErrInvalidContentTitle
+}
+
+// ParseContentTitle parses a content title text following the Digital Cinema Naming Convention.
+//
+// Title, content type, aspect ratio, languages, territory, audio, resolution, studio and date are required.
+// Facility, standard and package type are optional, but must be in that order.
+// If any field is not valid the returned error is of type ContentTitleErrors, listing every invalid field,
+// and the content title is returned along with it holding the fields that could be parsed.
+func ParseContentTitle(s string) (*ContentTitle, error) {
+	c := new(ContentTitle)
+	var errs ContentTitleErrors
+	invalid := func(field, value, reason string) {
+		errs = append(errs, &ContentTitleError{Field: field, Value: value, Reason: reason})
+	}
+
+	fields := strings.Split(strings.TrimSpace(s), contentTitleSeparator)
+	start := -1
+	for i := 1; i < len(fields); i++ {
+		if contentTypes[strings.SplitN(fields[i], contentTitleModifiersSeparator, 2)[0]] {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		invalid("content type", s, "not found")
+		return c, errs
+	}
+
+	c.Title = strings.Join(fields[:start], contentTitleSeparator)
+	for _, f := range fields[:start] {
+		if !dcncTitlePattern.MatchString(f) {
+			invalid("title", c.Title, "must be letters, digits and dashes")
+			break
+		}
+	}
+
+	// Mandatory fields after the title, in order.
+	const mandatory = 8
+	fields = fields[start:]
+	if len(fields) < mandatory {
+		invalid("content title", s, fmt.Sprintf("has %d fields after the title, expected at least %d", len(fields), mandatory))
+		return c, errs
+	}
+
+	parts := strings.Split(fields[0], contentTitleModifiersSeparator)
+	c.ContentType, c.ContentTypeModifiers = parts[0], dcncModifiers(parts)
+	for _, m := range c.ContentTypeModifiers {
+		if m == "" {
+			invalid("content type", fields[0], "has an empty modifier")
+			break
+		}
+	}
+
+	if m := dcncAspectPattern.FindStringSubmatch(fields[1]); m != nil {
+		c.AspectRatio, c.ImageAspectRatio = m[1], m[3]
+	} else {
+		invalid("aspect ratio", fields[1], "must be F, S or C optionally followed by the image aspect ratio")
+	}
+
+	parts = strings.Split(fields[2], contentTitleModifiersSeparator)
+	c.AudioLanguage = parts[0]
+	if !dcncLanguagePattern.MatchString(c.AudioLanguage) {
+		invalid("audio language", fields[2], "must be a 2 or 3 letter language code")
+	}
+	if len(parts) > 1 {
+		c.SubtitleLanguage, c.LanguageModifiers = parts[1], dcncModifiers(parts[1:])
+		if !dcncLanguagePattern.MatchString(c.SubtitleLanguage) {
+			invalid("subtitle language", fields[2], "must be a 2 or 3 letter language code")
+		}
+	}
+
+	parts = strings.SplitN(fields[3], contentTitleModifiersSeparator, 2)
+	c.Territory = parts[0]
+	if len(parts) > 1 {
+		c.Rating = parts[1]
+	}
+	if !dcncTerritoryPattern.MatchString(c.Territory) {
+		invalid("territory", fields[3], "must be a 2 or 3 letter upper case country code")
+	}
+	if len(parts) > 1 && !dcncRatingPattern.MatchString(c.Rating) {
+		invalid("rating", fields[3], "must be letters, digits, plus signs and dashes")
+	}
+
+	parts = strings.Split(fields[4], contentTitleModifiersSeparator)
+	c.AudioFormat, c.AudioModifiers = parts[0], dcncModifiers(parts)
+	if !dcncAudioPattern.MatchString(c.AudioFormat) {
+		invalid("audio", fields[4], "must start with a 2 digit channel configuration or MOS")
+	}
+
+	c.Resolution = fields[5]
+	if !dcncResolutionPattern.MatchString(c.Resolution) {
+		invalid("resolution", fields[5], "must be like 2K or 4K")
+	}
+
+	c.Studio = fields[6]
+	if !dcncCodePattern.MatchString(c.Studio) {
+		invalid("studio", fields[6], "must be letters, digits and dashes")
+	}
+
+	date, err := time.Parse(contentTitleDateLayout, fields[7])
+	if err != nil {
+		invalid("date", fields[7], "must be formatted as YYYYMMDD")
+	}
+	c.Date = date
+
+	// Optional fields are recognised from the end, as any of them may be missing.
+	optional := fields[mandatory:]
+	if n := len(optional); n > 0 && dcncPackageTypePattern.MatchString(optional[n-1]) {
+		c.PackageType = optional[n-1]
+		optional = optional[:n-1]
+	}
+	if n := len(optional); n > 0 && dcncStandardPattern.MatchString(optional[n-1]) {
+		parts = strings.Split(optional[n-1], contentTitleModifiersSeparator)
+		c.Standard, c.StandardModifiers = parts[0], dcncModifiers(parts)
+		optional = optional[:n-1]
+	}
+	switch len(optional) {
+	case 0:
+	case 1:
+		c.Facility = optional[0]
+		if !dcncCodePattern.MatchString(c.Facility) {
+			invalid("facility", optional[0], "must be letters, digits and dashes")
+		}
+	default:
+		invalid("content title", s, "has unexpected fields after the date: "+strings.Join(optional, contentTitleSeparator))
+	}
+
+	if len(errs) > 0 {
+		return c, errs
+	}
+	return c, nil
+}
+
+// dcncModifiers returns the modifiers following the first of the dash separated parts of a field, or nil if there are none.
+func dcncModifiers(parts []string) []string {
+	if len(parts) < 2 {
+		return nil
+	}
+	return parts[1:]
+}
+
+// String formats the content title text. It returns the text the content title was parsed from.
+func (c ContentTitle) String() string {
+	join := func(value string, modifiers []string) string {
+		return strings.Join(append([]string{value}, modifiers...), contentTitleModifiersSeparator)
+	}
+
+	aspect := c.AspectRatio
+	if c.ImageAspectRatio != "" {
+		aspect += contentTitleModifiersSeparator + c.ImageAspectRatio
+	}
+	language := c.AudioLanguage
+	if c.SubtitleLanguage != "" {
+		language = join(language, append([]string{c.SubtitleLanguage}, c.LanguageModifiers...))
+	}
+	territory := c.Territory
+	if c.Rating != "" {
+		territory += contentTitleModifiersSeparator + c.Rating
+	}
+
+	fields := []string{
+		c.Title,
+		join(c.ContentType, c.ContentTypeModifiers),
+		aspect,
+		language,
+		territory,
+		join(c.AudioFormat, c.AudioModifiers),
+		c.Resolution,
+		c.Studio,
+		c.Date.Format(contentTitleDateLayout),
+	}
+	if c.Facility != "" {
+		fields = append(fields, c.Facility)
+	}
+	if c.Standard != "" {
+		fields = append(fields, join(c.Standard, c.StandardModifiers))
+	}
+	if c.PackageType != "" {
+		fields = append(fields, c.PackageType)
+	}
+	return strings.Join(fields, contentTitleSeparator)
+}
+
+// Validate returns the errors ParseContentTitle would return for the formatted content title, or nil if it is valid.
+func (c ContentTitle) Validate() error {
+	_, err := ParseContentTitle(c.String())
+	return err
+}
+
+// Version returns the version of the content, written as a numeric content type modifier like "FTR-1" or "FTR-V3".
+// Zero is returned if there is no version.
+func (c ContentTitle) Version() int {
+	for _, m := range c.ContentTypeModifiers {
+		if v := dcncVersionPattern.FindStringSubmatch(m); v != nil {
+			version, _ := strconv.Atoi(v[1])
+			return version
+		}
+	}
+	return 0
+}
+
+// Is3D reports whether the content type is modified with "3D".
+func (c ContentTitle) Is3D() bool {
+	for _, m := range c.ContentTypeModifiers {
+		if strings.EqualFold(m, "3D") {
+			return true
+		}
+	}
+	return false
+}
+
+// HasSubtitles reports whether the content has subtitles.
+func (c ContentTitle) HasSubtitles() bool {
+	return c.SubtitleLanguage != "" && !strings.EqualFold(c.SubtitleLanguage, CONTENT_TITLE_NO_SUBTITLES)
+}
+
+// IsVersionFile reports whether the package is a version file, which depends on an original version.
+func (c ContentTitle) IsVersionFile() bool {
+	return strings.HasPrefix(c.PackageType, PACKAGE_TYPE_VERSION_FILE)
+}
+
+// ParseContentTitle parses the content title text of the CPL. See ParseContentTitle.
+func (c *MappedCPL) ParseContentTitle() (*ContentTitle, error) {
+	return ParseContentTitle(c.ContentTitleText)
+}
@@ -0,0 +1,151 @@
+package moviebuff
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseContentTitle(t *testing.T) {
+	var testCases = []struct {
+		desc           string
+		text           string
+		expected       *ContentTitle
+		expectedFields []string
+	}{
+		{
+			desc: "full content title",
+			text: "MOVIE_FTR-1_F_EN-XX_IN-UA_51_2K_STU_20240101_FAC_SMPTE_OV",
+			expected: &ContentTitle{
+				Title:                "MOVIE",
+				ContentType:          CONTENT_TYPE_FEATURE,
+				ContentTypeModifiers: []string{"1"},
+				AspectRatio:          "F",
+				AudioLanguage:        "EN",
+				SubtitleLanguage:     "XX",
+				Territory:            "IN",
+				Rating:               "UA",
+				AudioFormat:          "51",
+				Resolution:           "2K",
+				Studio:               "STU",
+				Date:                 time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Facility:             "FAC",
+				Standard:             DCP_STANDARD_SMPTE,
+				PackageType:          PACKAGE_TYPE_ORIGINAL_VERSION,
+			},
+		},
+		{
+			desc: "title qualifier and modifiers",
+			text: "AndhraKingThal_P2_FTR-2D-V3_S_TE-XX_IN-UA_51-Atmos_4K_ASV_20251125_ASV_SMPTE_VF",
+			expected: &ContentTitle{
+				Title:                "AndhraKingThal_P2",
+				ContentType:          CONTENT_TYPE_FEATURE,
+				ContentTypeModifiers: []string{"2D", "V3"},
+				AspectRatio:          "S",
+				AudioLanguage:        "TE",
+				SubtitleLanguage:     "XX",
+				Territory:            "IN",
+				Rating:               "UA",
+				AudioFormat:          "51",
+				AudioModifiers:       []string{"Atmos"},
+				Resolution:           "4K",
+				Studio:               "ASV",
+				Date:                 time.Date(2025, 11, 25, 0, 0, 0, 0, time.UTC),
+				Facility:             "ASV",
+				Standard:             DCP_STANDARD_SMPTE,
+				PackageType:          PACKAGE_TYPE_VERSION_FILE,
+			},
+		},
+		{
+			desc: "optional fields missing",
+			text: "TestMovie_TLR-F-178_C-185_HI-EN-CCAP_INT_71-HI-VI_2K_STU_20240301_IOP",
+			expected: &ContentTitle{
+				Title:                "TestMovie",
+				ContentType:          CONTENT_TYPE_TRAILER,
+				ContentTypeModifiers: []string{"F", "178"},
+				AspectRatio:          "C",
+				ImageAspectRatio:     "185",
+				AudioLanguage:        "HI",
+				SubtitleLanguage:     "EN",
+				LanguageModifiers:    []string{"CCAP"},
+				Territory:            "INT",
+				AudioFormat:          "71",
+				AudioModifiers:       []string{"HI", "VI"},
+				Resolution:           "2K",
+				Studio:               "STU",
+				Date:                 time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+				Standard:             DCP_STANDARD_INTEROP,
+			},
+		},
+		{
+			desc:           "no content type",
+			text:           "Movie_XYZ_F_EN-XX_IN-UA_51_2K_STU_20240101",
+			expected:       &ContentTitle{},
+			expectedFields: []string{"content type"},
+		},
+		{
+			desc:           "too few fields",
+			text:           "Movie_FTR_F_EN-XX",
+			expected:       &ContentTitle{Title: "Movie"},
+			expectedFields: []string{"content title"},
+		},
+		{
+			desc:           "invalid fields",
+			text:           "Movie_FTR_W_E1-XX_in-UA_5.1_2048_STU_20241301_FAC_SMPTE_OV",
+			expectedFields: []string{"aspect ratio", "audio language", "territory", "audio", "resolution", "date"},
+		},
+		{
+			desc:           "unexpected trailing fields",
+			text:           "Movie_FTR_F_EN-XX_IN-UA_51_2K_STU_20240101_FAC_EXTRA_SMPTE_OV",
+			expectedFields: []string{"content title"},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			c, err := ParseContentTitle(tC.text)
+			if tC.expectedFields == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tC.expected, c)
+				assert.Equal(t, tC.text, c.String())
+				assert.NoError(t, c.Validate())
+				return
+			}
+
+			assert.True(t, errors.Is(err, ErrInvalidContentTitle))
+			var errs ContentTitleErrors
+			assert.True(t, errors.As(err, &errs))
+			var fields []string
+			for _, e := range errs {
+				fields = append(fields, e.Field)
+			}
+			assert.Equal(t, tC.expectedFields, fields)
+			if tC.expected != nil {
+				assert.Equal(t, tC.expected, c)
+			}
+		})
+	}
+}
+
+func TestContentTitle_Helpers(t *testing.T) {
+	assert := assert.New(t)
+
+	c, err := ParseContentTitle("AndhraKingThal_P2_FTR-3D-V3_S_TE-EN_IN-UA_51-Atmos_4K_ASV_20251125_ASV_SMPTE_VF")
+	assert.NoError(err)
+	assert.Equal(3, c.Version())
+	assert.True(c.Is3D())
+	assert.True(c.HasSubtitles())
+	assert.True(c.IsVersionFile())
+
+	c, err = (&MappedCPL{ContentTitleText: "MOVIE_FTR-1_F_EN-XX_IN-UA_51_2K_STU_20240101_FAC_SMPTE_OV"}).ParseContentTitle()
+	assert.NoError(err)
+	assert.Equal(1, c.Version())
+	assert.False(c.Is3D())
+	assert.False(c.HasSubtitles())
+	assert.False(c.IsVersionFile())
+
+	c.Rating = "U/A"
+	assert.True(errors.Is(c.Validate(), ErrInvalidContentTitle))
+}
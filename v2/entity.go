@@ -48,13 +48,13 @@ type Entity struct {
 			Language       string            `json:"language"`
 			Type           string            `json:"type"`
 			UUID           string            `json:"uuid"`
-			Poster         interface{}       `json:"poster"`
+			Poster         TolerantString    `json:"poster"`
 			MoviebuffURL   string            `json:"moviebuffUrl"`
 			APIPath        string            `json:"apiPath"`
 			Role           string            `json:"role"`
 			Department     string            `json:"department"`
 			Primary        bool              `json:"primary"`
-			Character      interface{}       `json:"character"`
+			Character      TolerantString    `json:"character"`
 		} `json:"roles"`
 	} `json:"credits"`
 
@@ -65,33 +65,13 @@ type Entity struct {
 	Type string `json:"type"`
 
 	// An list of posters of the entity.
-	Posters []struct {
-		Featured bool   `json:"featured"`
-		URL      string `json:"url"`
-		Key      string `json:"key"`
-		Caption  string `json:"caption"`
-		Type     string `json:"type"`
-	} `json:"posters"`
+	Posters []Image `json:"posters"`
 
 	// An list of videos of the entity.
-	Videos []struct {
-		Featured  bool   `json:"featured"`
-		URL       string `json:"url"`
-		EmbedURL  string `json:"embedUrl"`
-		Key       string `json:"key"`
-		Caption   string `json:"caption"`
-		Thumbnail string `json:"thumbnail"`
-		Type      string `json:"type"`
-	} `json:"videos"`
+	Videos []Video `json:"videos"`
 
 	// An list of stills of the entity.
-	Stills []struct {
-		Featured bool   `json:"featured"`
-		URL      string `json:"url"`
-		Key      string `json:"key"`
-		Caption  string `json:"caption"`
-		Type     string `json:"type"`
-	} `json:"stills"`
+	Stills []Image `json:"stills"`
 
 	// The path to the entity in the current version of the API.
 	APIPath string `json:"apiPath"`
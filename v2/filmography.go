@@ -0,0 +1,190 @@
+package moviebuff
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// FilmographyEntry is a movie in the filmography of a person or an entity along with the role played in it.
+type FilmographyEntry struct {
+	// Name of the movie.
+	Name string
+
+	// Slug url of the movie.
+	URL string
+
+	// UUID of the movie.
+	UUID string
+
+	// Type of the resource.
+	Type string
+
+	// The primary language of the movie.
+	Language string
+
+	// Poster url of the movie.
+	Poster string
+
+	// Release Dates mapped against their corresponding country code like "IN" : "2013-12-20".
+	ReleaseDates map[string]string
+
+	// Certifications mapped against their corresponding country code like "IN" : "A".
+	Certifications map[string]string
+
+	// Role played in the movie like "Actor" or "Director".
+	Role string
+
+	// Department the role belongs to.
+	Department string
+
+	// Whether the role is a primary role in the movie.
+	Primary bool
+
+	// Character played in the movie, if any.
+	Character string
+
+	MoviebuffURL string
+	APIPath      string
+}
+
+// GetEarliestReleaseDate returns the date at which the movie was first released anywhere in the world.
+// ok is false if release date is not available
+func (e FilmographyEntry) GetEarliestReleaseDate() (releaseDate time.Time, ok bool) {
+	return earliestReleaseDate(e.ReleaseDates)
+}
+
+// Filmography is a list of movies a person or an entity is credited in.
+type Filmography []FilmographyEntry
+
+// GetFilmography returns the credits of the person flattened to one entry per role, in the order of the API.
+func (p *Person) GetFilmography() Filmography {
+	var filmography Filmography
+	for _, department := range p.Credits {
+		for _, r := range department.Roles {
+			e := FilmographyEntry{
+				Name:           r.Name,
+				URL:            r.URL,
+				UUID:           r.UUID,
+				Type:           r.Type,
+				Language:       r.Language,
+				Poster:         r.Poster,
+				ReleaseDates:   r.ReleaseDates,
+				Certifications: r.Certifications,
+				Role:           r.Role,
+				Department:     r.Department,
+				Primary:        r.Primary,
+				Character:      r.Character,
+				MoviebuffURL:   r.MoviebuffURL,
+				APIPath:        r.APIPath,
+			}
+			if e.Department == "" {
+				e.Department = department.Department
+			}
+			filmography = append(filmography, e)
+		}
+	}
+	return filmography
+}
+
+// GetFilmography returns the credits of the entity flattened to one entry per role, in the order of the API.
+func (en *Entity) GetFilmography() Filmography {
+	var filmography Filmography
+	for _, department := range en.Credits {
+		for _, r := range department.Roles {
+			e := FilmographyEntry{
+				Name:           r.Name,
+				URL:            r.URL,
+				UUID:           r.UUID,
+				Type:           r.Type,
+				Language:       r.Language,
+				Poster:         string(r.Poster),
+				ReleaseDates:   r.ReleaseDates,
+				Certifications: r.Certifications,
+				Role:           r.Role,
+				Department:     r.Department,
+				Primary:        r.Primary,
+				Character:      string(r.Character),
+				MoviebuffURL:   r.MoviebuffURL,
+				APIPath:        r.APIPath,
+			}
+			if e.Department == "" {
+				e.Department = department.Department
+			}
+			filmography = append(filmography, e)
+		}
+	}
+	return filmography
+}
+
+// SortByReleaseDate sorts the filmography by earliest release date, oldest first.
+// Entries without a release date are moved to the end, keeping their order.
+func (f Filmography) SortByReleaseDate() {
+	sort.SliceStable(f, func(i, j int) bool {
+		ti, iok := f[i].GetEarliestReleaseDate()
+		tj, jok := f[j].GetEarliestReleaseDate()
+		if iok != jok {
+			return iok
+		}
+		return ti.Before(tj)
+	})
+}
+
+// Filter returns the entries for which keep returns true.
+func (f Filmography) Filter(keep func(FilmographyEntry) bool) Filmography {
+	var filtered Filmography
+	for _, e := range f {
+		if keep(e) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// ByLanguage returns the entries of movies in the given language. Language is compared case insensitively.
+func (f Filmography) ByLanguage(language string) Filmography {
+	return f.Filter(func(e FilmographyEntry) bool {
+		return strings.EqualFold(strings.TrimSpace(e.Language), strings.TrimSpace(language))
+	})
+}
+
+// ByYearRange returns the entries of movies first released between the years from and to, both inclusive.
+// Zero value of from or to leaves that end of the range open. Entries without a release date are dropped.
+func (f Filmography) ByYearRange(from, to int) Filmography {
+	return f.Filter(func(e FilmographyEntry) bool {
+		t, ok := e.GetEarliestReleaseDate()
+		if !ok {
+			return false
+		}
+		return (from == 0 || t.Year() >= from) && (to == 0 || t.Year() <= to)
+	})
+}
+
+// PrimaryOnly returns the entries of primary roles.
+func (f Filmography) PrimaryOnly() Filmography {
+	return f.Filter(func(e FilmographyEntry) bool {
+		return e.Primary
+	})
+}
+
+// ByDepartment returns the entries of the given department like "Direction".
+// Department is compared ignoring case and punctuation.
+func (f Filmography) ByDepartment(department string) Filmography {
+	department = normalizeName(department)
+	return f.Filter(func(e FilmographyEntry) bool {
+		return normalizeName(e.Department) == department
+	})
+}
+
+// Split splits the filmography into movies released on or before now and upcoming movies.
+// Movies without a release date are considered upcoming.
+func (f Filmography) Split(now time.Time) (released, upcoming Filmography) {
+	for _, e := range f {
+		if t, ok := e.GetEarliestReleaseDate(); ok && !t.After(now) {
+			released = append(released, e)
+		} else {
+			upcoming = append(upcoming, e)
+		}
+	}
+	return
+}
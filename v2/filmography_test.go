@@ -0,0 +1,85 @@
+package moviebuff
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const filmographyPerson = `{
+	"name": "Test_Person",
+	"type": "person",
+	"credits": [
+		{
+			"department": "Cast",
+			"roles": [
+				{"name": "Upcoming", "language": "Hindi", "role": "Actor", "primary": true, "releaseDates": {"IN": "2030-01-01"}},
+				{"name": "Old", "language": "Tamil", "role": "Actor", "primary": false, "character": "Guest", "releaseDates": {"IN": "2005-05-05"}},
+				{"name": "Undated", "language": "Hindi", "role": "Actor", "primary": true}
+			]
+		},
+		{
+			"department": "Direction",
+			"roles": [
+				{"name": "Debut", "language": "hindi", "role": "Director", "department": "Direction", "primary": true, "releaseDates": {"US": "2012-02-03", "IN": "2012-02-01"}}
+			]
+		}
+	]
+}`
+
+func filmographyNames(f Filmography) []string {
+	var n []string
+	for _, e := range f {
+		n = append(n, e.Name)
+	}
+	return n
+}
+
+func TestPerson_GetFilmography(t *testing.T) {
+	assert := assert.New(t)
+
+	p := new(Person)
+	assert.NoError(json.Unmarshal([]byte(filmographyPerson), p))
+
+	f := p.GetFilmography()
+	assert.Equal([]string{"Upcoming", "Old", "Undated", "Debut"}, filmographyNames(f))
+	assert.Equal("Cast", f[0].Department)
+	assert.Equal("Guest", f[1].Character)
+
+	f.SortByReleaseDate()
+	assert.Equal([]string{"Old", "Debut", "Upcoming", "Undated"}, filmographyNames(f))
+
+	releaseDate, ok := f[1].GetEarliestReleaseDate()
+	assert.True(ok)
+	assert.Equal(time.Date(2012, 2, 1, 0, 0, 0, 0, time.UTC), releaseDate)
+
+	assert.Equal([]string{"Debut", "Upcoming", "Undated"}, filmographyNames(f.ByLanguage("HINDI")))
+	assert.Equal([]string{"Old", "Debut"}, filmographyNames(f.ByYearRange(0, 2020)))
+	assert.Equal([]string{"Debut", "Upcoming"}, filmographyNames(f.ByYearRange(2010, 0)))
+	assert.Equal([]string{"Debut", "Upcoming", "Undated"}, filmographyNames(f.PrimaryOnly()))
+	assert.Equal([]string{"Debut"}, filmographyNames(f.ByDepartment("direction")))
+
+	released, upcoming := f.Split(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal([]string{"Old", "Debut"}, filmographyNames(released))
+	assert.Equal([]string{"Upcoming", "Undated"}, filmographyNames(upcoming))
+}
+
+func TestEntity_GetFilmography(t *testing.T) {
+	assert := assert.New(t)
+
+	e := new(Entity)
+	assert.NoError(json.Unmarshal([]byte(`{
+		"credits": [{
+			"department": "Production",
+			"roles": [{"name": "Produced", "role": "Production Company", "poster": "poster.jpg", "character": null}]
+		}]
+	}`), e))
+
+	f := e.GetFilmography()
+	assert.Len(f, 1)
+	assert.Equal("Production", f[0].Department)
+	assert.Equal("poster.jpg", f[0].Poster)
+	assert.Equal("", f[0].Character)
+}
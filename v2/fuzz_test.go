@@ -0,0 +1,79 @@
+//go:build go1.18
+// +build go1.18
+
+package moviebuff
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// oddPayloads seed every fuzz test with payloads the API should never return.
+var oddPayloads = []string{
+	``,
+	`null`,
+	`{}`,
+	`[]`,
+	`"movie"`,
+	`42`,
+	`{"name": 1, "releaseDates": [], "certifications": "UA"}`,
+	`{"releaseStatuses": {"IN": 1}, "techDetails": [{"data": {"nested": [null]}}]}`,
+	`{"credits": [{"roles": [{"poster": {"url": null}, "character": [1, "a"]}]}]}`,
+	`{"data": null, "holidays": [null], "movie": {"part": null}}`,
+	`[{"spoken_in": null}, null]`,
+}
+
+// fuzzDecode checks that decoding any payload into the value returned by newValue does not panic,
+// and that whatever decodes can be encoded again.
+func fuzzDecode(f *testing.F, fixture string, newValue func() interface{}) {
+	f.Add(readGoldenFixture(f, fixture))
+	for _, payload := range oddPayloads {
+		f.Add([]byte(payload))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		v := newValue()
+		if err := json.Unmarshal(data, v); err != nil {
+			return
+		}
+		if _, err := json.Marshal(v); err != nil {
+			t.Errorf("decoded %q but could not encode it: %v", data, err)
+		}
+	})
+}
+
+func FuzzMovieDecode(f *testing.F) {
+	fuzzDecode(f, "movie.json", func() interface{} { return new(Movie) })
+}
+
+func FuzzPersonDecode(f *testing.F) {
+	fuzzDecode(f, "person.json", func() interface{} { return new(Person) })
+}
+
+func FuzzEntityDecode(f *testing.F) {
+	fuzzDecode(f, "entity.json", func() interface{} { return new(Entity) })
+}
+
+func FuzzResourcesDecode(f *testing.F) {
+	fuzzDecode(f, "resources.json", func() interface{} { return new(Resources) })
+}
+
+func FuzzCertificationsDecode(f *testing.F) {
+	fuzzDecode(f, "certifications.json", func() interface{} {
+		return new(struct {
+			Data []Certification `json:"data"`
+		})
+	})
+}
+
+func FuzzCalendarDecode(f *testing.F) {
+	fuzzDecode(f, "calendar.json", func() interface{} { return new(Calendar) })
+}
+
+func FuzzLanguagesDecode(f *testing.F) {
+	fuzzDecode(f, "languages.json", func() interface{} { return new([]Language) })
+}
+
+func FuzzMappedCPLDecode(f *testing.F) {
+	fuzzDecode(f, "mapped_cpl.json", func() interface{} { return new(MappedCPL) })
+}
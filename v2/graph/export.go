@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteGraphML writes the collaborations between people and entities as a GraphML document.
+// Nodes carry the name and type of the person or entity, edges the number of movies worked on together.
+func (g *Graph) WriteGraphML(w io.Writer) error {
+	g.mu.RLock()
+	nodes, edges := g.edges()
+	g.mu.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(xml.Header)
+	bw.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	bw.WriteString(`  <key id="name" for="node" attr.name="name" attr.type="string"/>` + "\n")
+	bw.WriteString(`  <key id="type" for="node" attr.name="type" attr.type="string"/>` + "\n")
+	bw.WriteString(`  <key id="movies" for="edge" attr.name="movies" attr.type="int"/>` + "\n")
+	bw.WriteString(`  <graph id="collaborations" edgedefault="undirected">` + "\n")
+
+	for _, n := range nodes {
+		fmt.Fprintf(bw, "    <node id=\"%s\">\n", escapeXML(n.UUID))
+		fmt.Fprintf(bw, "      <data key=\"name\">%s</data>\n", escapeXML(n.Name))
+		fmt.Fprintf(bw, "      <data key=\"type\">%s</data>\n", escapeXML(n.Type))
+		bw.WriteString("    </node>\n")
+	}
+	for _, e := range edges {
+		fmt.Fprintf(bw, "    <edge source=\"%s\" target=\"%s\">\n", escapeXML(e.a), escapeXML(e.b))
+		fmt.Fprintf(bw, "      <data key=\"movies\">%d</data>\n", e.movies)
+		bw.WriteString("    </edge>\n")
+	}
+
+	bw.WriteString("  </graph>\n</graphml>\n")
+	return bw.Flush()
+}
+
+// WriteDOT writes the collaborations between people and entities as a Graphviz DOT graph.
+// Nodes are labelled with the name of the person or entity, edges with the number of movies worked on together.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	g.mu.RLock()
+	nodes, edges := g.edges()
+	g.mu.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("graph collaborations {\n")
+	for _, n := range nodes {
+		shape := "ellipse"
+		if n.Type == NODE_TYPE_ENTITY {
+			shape = "box"
+		}
+		fmt.Fprintf(bw, "  %s [label=%s, shape=%s];\n", quoteDOT(n.UUID), quoteDOT(n.Name), shape)
+	}
+	for _, e := range edges {
+		fmt.Fprintf(bw, "  %s -- %s [weight=%d, label=\"%d\"];\n", quoteDOT(e.a), quoteDOT(e.b), e.movies, e.movies)
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+func escapeXML(s string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+func quoteDOT(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
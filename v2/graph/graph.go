@@ -0,0 +1,315 @@
+// Package graph builds an in-memory collaboration graph of the people and entities credited in movies.
+//
+// Two people or entities collaborated if they are credited in the same movie. The graph is built
+// from the cast, crew and music labels of movies and from the credits of people and entities,
+// and can answer who someone works with most, how far apart two people are, and be exported
+// to GraphML or DOT for visualisation.
+package graph
+
+import (
+	"sort"
+	"sync"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+)
+
+// Type of nodes
+const (
+	NODE_TYPE_PERSON = "person"
+	NODE_TYPE_ENTITY = "entity"
+	NODE_TYPE_MOVIE  = "movie"
+)
+
+// Node is a person, an entity or a movie in the graph.
+type Node struct {
+	UUID string
+	Name string
+	Type string
+}
+
+// Collaborator is a person or an entity who worked with another on one or more movies.
+type Collaborator struct {
+	Node
+
+	// Movies they both worked on, sorted by UUID.
+	Movies []string
+}
+
+// Step is a hop of a path between two people or entities.
+type Step struct {
+	// Node reached by this step.
+	Node Node
+
+	// Movie through which Node was reached. Empty for the first step of a path.
+	Movie Node
+}
+
+// Graph is a collaboration graph. It is safe for concurrent use.
+type Graph struct {
+	mu sync.RWMutex
+
+	// nodes maps the UUID of people, entities and movies to their node.
+	nodes map[string]Node
+
+	// credited maps the UUID of a movie to the UUIDs of the people and entities credited in it.
+	credited map[string]map[string]bool
+
+	// worked maps the UUID of a person or entity to the UUIDs of the movies they are credited in.
+	worked map[string]map[string]bool
+}
+
+// New returns an empty graph.
+func New() *Graph {
+	return &Graph{
+		nodes:    map[string]Node{},
+		credited: map[string]map[string]bool{},
+		worked:   map[string]map[string]bool{},
+	}
+}
+
+// AddMovie adds the cast, crew and music labels of a movie to the graph.
+func (g *Graph) AddMovie(m *moviebuff.Movie) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	movie := Node{UUID: m.UUID, Name: m.Name, Type: NODE_TYPE_MOVIE}
+	for _, c := range m.GetCredits() {
+		g.link(movie, Node{UUID: c.UUID, Name: c.Name, Type: nodeType(c.Type, NODE_TYPE_PERSON)})
+	}
+	for _, l := range m.MusicLabels {
+		g.link(movie, Node{UUID: l.UUID, Name: l.Name, Type: nodeType(l.Type, NODE_TYPE_ENTITY)})
+	}
+}
+
+// AddPerson adds the credits of a person to the graph.
+func (g *Graph) AddPerson(p *moviebuff.Person) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	person := Node{UUID: p.UUID, Name: p.Name, Type: NODE_TYPE_PERSON}
+	for _, e := range p.GetFilmography() {
+		g.link(Node{UUID: e.UUID, Name: e.Name, Type: NODE_TYPE_MOVIE}, person)
+	}
+}
+
+// AddEntity adds the credits of an entity to the graph.
+func (g *Graph) AddEntity(en *moviebuff.Entity) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	entity := Node{UUID: en.UUID, Name: en.Name, Type: NODE_TYPE_ENTITY}
+	for _, e := range en.GetFilmography() {
+		g.link(Node{UUID: e.UUID, Name: e.Name, Type: NODE_TYPE_MOVIE}, entity)
+	}
+}
+
+// nodeType returns t normalised to one of the node types, or def if t is empty.
+func nodeType(t, def string) string {
+	switch t {
+	case "":
+		return def
+	case "people":
+		return NODE_TYPE_PERSON
+	case "entities":
+		return NODE_TYPE_ENTITY
+	}
+	return t
+}
+
+// link records that the person or entity n is credited in movie.
+func (g *Graph) link(movie, n Node) {
+	if movie.UUID == "" || n.UUID == "" {
+		return
+	}
+	g.addNode(movie)
+	g.addNode(n)
+
+	if g.credited[movie.UUID] == nil {
+		g.credited[movie.UUID] = map[string]bool{}
+	}
+	g.credited[movie.UUID][n.UUID] = true
+
+	if g.worked[n.UUID] == nil {
+		g.worked[n.UUID] = map[string]bool{}
+	}
+	g.worked[n.UUID][movie.UUID] = true
+}
+
+// addNode adds n to the graph, keeping the name already known if n has none.
+func (g *Graph) addNode(n Node) {
+	if existing, ok := g.nodes[n.UUID]; ok && n.Name == "" {
+		n.Name = existing.Name
+	}
+	g.nodes[n.UUID] = n
+}
+
+// Node returns the node with the given UUID.
+// ok is false if the graph has no such node
+func (g *Graph) Node(uuid string) (node Node, ok bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	node, ok = g.nodes[uuid]
+	return
+}
+
+// Collaborators returns everyone who worked with the person or entity with the given UUID,
+// sorted by the number of movies worked on together, most first, and then by name.
+func (g *Graph) Collaborators(uuid string) []Collaborator {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.collaborators(uuid)
+}
+
+// TopCollaborators returns the n people or entities who worked most often with the one with the given UUID.
+func (g *Graph) TopCollaborators(uuid string, n int) []Collaborator {
+	collaborators := g.Collaborators(uuid)
+	if n >= 0 && len(collaborators) > n {
+		collaborators = collaborators[:n]
+	}
+	return collaborators
+}
+
+func (g *Graph) collaborators(uuid string) []Collaborator {
+	shared := map[string][]string{}
+	for movie := range g.worked[uuid] {
+		for other := range g.credited[movie] {
+			if other != uuid {
+				shared[other] = append(shared[other], movie)
+			}
+		}
+	}
+
+	collaborators := make([]Collaborator, 0, len(shared))
+	for other, movies := range shared {
+		sort.Strings(movies)
+		collaborators = append(collaborators, Collaborator{Node: g.nodes[other], Movies: movies})
+	}
+
+	sort.Slice(collaborators, func(i, j int) bool {
+		a, b := collaborators[i], collaborators[j]
+		if len(a.Movies) != len(b.Movies) {
+			return len(a.Movies) > len(b.Movies)
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.UUID < b.UUID
+	})
+	return collaborators
+}
+
+// ShortestPath returns a shortest chain of collaborations from one person or entity to another.
+// The first step is from itself, every following step is a collaborator of the previous one
+// along with the movie they worked on together.
+// ok is false if the two are not connected
+func (g *Graph) ShortestPath(from, to string) (path []Step, ok bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if _, known := g.worked[from]; !known {
+		return nil, false
+	}
+	if _, known := g.worked[to]; !known {
+		return nil, false
+	}
+
+	type hop struct {
+		prev  string
+		movie string
+	}
+	reached := map[string]hop{from: {}}
+	queue := []string{from}
+
+	for len(queue) > 0 {
+		if _, done := reached[to]; done {
+			break
+		}
+
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, movie := range sortedKeys(g.worked[current]) {
+			for _, next := range sortedKeys(g.credited[movie]) {
+				if _, seen := reached[next]; seen {
+					continue
+				}
+				reached[next] = hop{prev: current, movie: movie}
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	if _, done := reached[to]; !done {
+		return nil, false
+	}
+
+	for uuid := to; ; {
+		h := reached[uuid]
+		step := Step{Node: g.nodes[uuid]}
+		if uuid != from {
+			step.Movie = g.nodes[h.movie]
+		}
+		path = append([]Step{step}, path...)
+		if uuid == from {
+			break
+		}
+		uuid = h.prev
+	}
+	return path, true
+}
+
+// Separation returns the degrees of separation between two people or entities:
+// 0 for the same, 1 for collaborators, 2 for collaborators of collaborators and so on.
+// ok is false if the two are not connected
+func (g *Graph) Separation(from, to string) (degrees int, ok bool) {
+	path, ok := g.ShortestPath(from, to)
+	if !ok {
+		return 0, false
+	}
+	return len(path) - 1, true
+}
+
+// edge is a collaboration between two people or entities, with a < b.
+type edge struct {
+	a, b   string
+	movies int
+}
+
+// edges returns the people and entities of the graph and the collaborations between them, sorted.
+func (g *Graph) edges() ([]Node, []edge) {
+	nodes := make([]Node, 0, len(g.worked))
+	for uuid := range g.worked {
+		nodes = append(nodes, g.nodes[uuid])
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].UUID < nodes[j].UUID
+	})
+
+	var edges []edge
+	for _, n := range nodes {
+		for _, c := range g.collaborators(n.UUID) {
+			if n.UUID < c.UUID {
+				edges = append(edges, edge{a: n.UUID, b: c.UUID, movies: len(c.Movies)})
+			}
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].a != edges[j].a {
+			return edges[i].a < edges[j].a
+		}
+		return edges[i].b < edges[j].b
+	})
+	return nodes, edges
+}
+
+// sortedKeys returns the keys of a set, sorted so that traversals are deterministic.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
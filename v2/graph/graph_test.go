@@ -0,0 +1,112 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func movie(t *testing.T, data string) *moviebuff.Movie {
+	m := new(moviebuff.Movie)
+	if err := json.Unmarshal([]byte(data), m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func testGraph(t *testing.T) *Graph {
+	g := New()
+	g.AddMovie(movie(t, `{
+		"uuid": "m1", "name": "Movie One",
+		"cast": [{"uuid": "actor", "name": "Actor", "type": "person"}, {"uuid": "actress", "name": "Actress"}],
+		"crew": [{"department": "Direction", "roles": [{"uuid": "director", "name": "Director"}]}],
+		"musicLabels": [{"uuid": "label", "name": "Label & Co"}]
+	}`))
+	g.AddMovie(movie(t, `{
+		"uuid": "m2", "name": "Movie Two",
+		"cast": [{"uuid": "actor", "name": "Actor"}],
+		"crew": [{"department": "Direction", "roles": [{"uuid": "director", "name": "Director"}]}]
+	}`))
+
+	p := new(moviebuff.Person)
+	if err := json.Unmarshal([]byte(`{
+		"uuid": "newcomer", "name": "Newcomer \"N\"",
+		"credits": [{"department": "Cast", "roles": [{"uuid": "m3", "name": "Movie Three"}]}]
+	}`), p); err != nil {
+		t.Fatal(err)
+	}
+	g.AddPerson(p)
+
+	e := new(moviebuff.Entity)
+	if err := json.Unmarshal([]byte(`{
+		"uuid": "studio", "name": "Studio",
+		"credits": [{"department": "Production", "roles": [{"uuid": "m2", "name": "Movie Two"}, {"uuid": "m3", "name": "Movie Three"}]}]
+	}`), e); err != nil {
+		t.Fatal(err)
+	}
+	g.AddEntity(e)
+	return g
+}
+
+func TestGraph_TopCollaborators(t *testing.T) {
+	assert := assert.New(t)
+	g := testGraph(t)
+
+	collaborators := g.TopCollaborators("actor", 2)
+	assert.Len(collaborators, 2)
+	assert.Equal("Director", collaborators[0].Name)
+	assert.Equal([]string{"m1", "m2"}, collaborators[0].Movies)
+	assert.Equal("Actress", collaborators[1].Name)
+
+	assert.Len(g.Collaborators("actor"), 4)
+	assert.Empty(g.Collaborators("unknown"))
+
+	node, ok := g.Node("label")
+	assert.True(ok)
+	assert.Equal(NODE_TYPE_ENTITY, node.Type)
+}
+
+func TestGraph_ShortestPath(t *testing.T) {
+	assert := assert.New(t)
+	g := testGraph(t)
+
+	path, ok := g.ShortestPath("actress", "newcomer")
+	assert.True(ok)
+	var hops []string
+	for _, s := range path {
+		hops = append(hops, s.Node.UUID+"@"+s.Movie.UUID)
+	}
+	assert.Equal([]string{"actress@", "actor@m1", "studio@m2", "newcomer@m3"}, hops)
+
+	degrees, ok := g.Separation("actress", "newcomer")
+	assert.True(ok)
+	assert.Equal(3, degrees)
+
+	degrees, ok = g.Separation("actor", "actor")
+	assert.True(ok)
+	assert.Equal(0, degrees)
+
+	_, ok = g.Separation("actor", "unknown")
+	assert.False(ok)
+}
+
+func TestGraph_Export(t *testing.T) {
+	assert := assert.New(t)
+	g := testGraph(t)
+
+	buf := new(bytes.Buffer)
+	assert.NoError(g.WriteGraphML(buf))
+	assert.Contains(buf.String(), `<data key="name">Label &amp; Co</data>`)
+	assert.Contains(buf.String(), "<edge source=\"actor\" target=\"director\">\n      <data key=\"movies\">2</data>")
+	assert.Equal(6, strings.Count(buf.String(), "<node "))
+
+	buf.Reset()
+	assert.NoError(g.WriteDOT(buf))
+	assert.Contains(buf.String(), `"newcomer" [label="Newcomer \"N\"", shape=ellipse];`)
+	assert.Contains(buf.String(), `"studio" [label="Studio", shape=box];`)
+	assert.Contains(buf.String(), `"actor" -- "director" [weight=2, label="2"];`)
+}
@@ -0,0 +1,188 @@
+package moviebuff
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// Type of change of a holiday between two syncs
+type HolidayChangeType string
+
+const (
+	HOLIDAY_ADDED   HolidayChangeType = "added"
+	HOLIDAY_REMOVED HolidayChangeType = "removed"
+	HOLIDAY_RENAMED HolidayChangeType = "renamed"
+	HOLIDAY_MOVED   HolidayChangeType = "moved"
+)
+
+// HolidayChange is a change of a holiday between two syncs of a country's calendar.
+// A holiday whose name changed is reported as renamed, even if its date changed too.
+type HolidayChange struct {
+	Country string
+	Type    HolidayChangeType
+
+	// Old is the holiday before the change. Nil for added holidays.
+	Old *Holiday
+
+	// New is the holiday after the change. Nil for removed holidays.
+	New *Holiday
+}
+
+// HolidaySyncState is what a HolidaySyncer remembers about a country's calendar between syncs.
+// SyncToken is the sync token of the last calendar fetched.
+type HolidaySyncState struct {
+	SyncToken string    `json:"syncToken"`
+	Holidays  []Holiday `json:"holidays"`
+}
+
+// HolidaySyncStore stores the sync state of every country.
+type HolidaySyncStore interface {
+	// Load returns the state of the country, or nil if it was never synced.
+	Load(ctx context.Context, country string) (*HolidaySyncState, error)
+
+	// Save stores the state of the country.
+	Save(ctx context.Context, country string, state *HolidaySyncState) error
+}
+
+// MemoryHolidaySyncStore is a HolidaySyncStore keeping states in memory. It is safe for concurrent use.
+type MemoryHolidaySyncStore struct {
+	mu     sync.Mutex
+	states map[string]HolidaySyncState
+}
+
+// NewMemoryHolidaySyncStore returns an empty MemoryHolidaySyncStore.
+func NewMemoryHolidaySyncStore() *MemoryHolidaySyncStore {
+	return &MemoryHolidaySyncStore{states: map[string]HolidaySyncState{}}
+}
+
+func (s *MemoryHolidaySyncStore) Load(ctx context.Context, country string) (*HolidaySyncState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.states[country]
+	if !ok {
+		return nil, nil
+	}
+	state.Holidays = append([]Holiday(nil), state.Holidays...)
+	return &state, nil
+}
+
+func (s *MemoryHolidaySyncStore) Save(ctx context.Context, country string, state *HolidaySyncState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.states[country] = HolidaySyncState{
+		SyncToken: state.SyncToken,
+		Holidays:  append([]Holiday(nil), state.Holidays...),
+	}
+	return nil
+}
+
+// HolidaySyncer keeps the holiday calendars of countries in sync and reports what changed.
+type HolidaySyncer struct {
+	mb    Moviebuff
+	store HolidaySyncStore
+
+	// onChange is called with the changes of every sync that changed something.
+	onChange func(ctx context.Context, country string, changes []HolidayChange) error
+}
+
+// NewHolidaySyncer returns a syncer fetching calendars from mb, storing sync states in store
+// and reporting changes to onChange.
+//
+// If onChange returns an error the sync state is not saved, so the same changes are reported again on the next sync.
+func NewHolidaySyncer(mb Moviebuff, store HolidaySyncStore,
+	onChange func(ctx context.Context, country string, changes []HolidayChange) error) *HolidaySyncer {
+	return &HolidaySyncer{
+		mb:       mb,
+		store:    store,
+		onChange: onChange,
+	}
+}
+
+// Sync syncs the holiday calendar of a country and returns the changes since the last sync.
+// Every holiday is reported as added on the first sync of a country.
+//
+// The full calendar is fetched and compared with the last sync: the API documents no way
+// to request only the holidays changed since a sync token.
+func (s *HolidaySyncer) Sync(ctx context.Context, country string) ([]HolidayChange, error) {
+	state, err := s.store.Load(ctx, country)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		state = new(HolidaySyncState)
+	}
+
+	calendar, err := s.mb.GetHolidayCalendar(ctx, country)
+	if err != nil {
+		return nil, err
+	}
+	next := &HolidaySyncState{
+		SyncToken: calendar.SyncToken,
+		Holidays:  calendar.Holidays,
+	}
+
+	changes := DiffHolidays(country, state.Holidays, next.Holidays)
+	if len(changes) > 0 && s.onChange != nil {
+		if err := s.onChange(ctx, country, changes); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.store.Save(ctx, country, next); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// DiffHolidays returns the changes between two versions of a country's holidays, matched by their ID.
+// Changes are sorted by date and then by ID.
+func DiffHolidays(country string, old, new []Holiday) []HolidayChange {
+	oldByID := make(map[string]Holiday, len(old))
+	for _, h := range old {
+		oldByID[h.ID] = h
+	}
+	newByID := make(map[string]Holiday, len(new))
+	for _, h := range new {
+		newByID[h.ID] = h
+	}
+
+	var changes []HolidayChange
+	for _, h := range new {
+		h := h
+		previous, ok := oldByID[h.ID]
+		switch {
+		case !ok:
+			changes = append(changes, HolidayChange{Country: country, Type: HOLIDAY_ADDED, New: &h})
+		case previous.Name != h.Name:
+			changes = append(changes, HolidayChange{Country: country, Type: HOLIDAY_RENAMED, Old: &previous, New: &h})
+		case previous.Date != h.Date:
+			changes = append(changes, HolidayChange{Country: country, Type: HOLIDAY_MOVED, Old: &previous, New: &h})
+		}
+	}
+	for _, h := range old {
+		h := h
+		if _, ok := newByID[h.ID]; !ok {
+			changes = append(changes, HolidayChange{Country: country, Type: HOLIDAY_REMOVED, Old: &h})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		a, b := changes[i].holiday(), changes[j].holiday()
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		return a.ID < b.ID
+	})
+	return changes
+}
+
+// holiday returns the holiday after the change, or before it for removed holidays.
+func (c HolidayChange) holiday() *Holiday {
+	if c.New != nil {
+		return c.New
+	}
+	return c.Old
+}
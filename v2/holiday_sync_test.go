@@ -0,0 +1,119 @@
+package moviebuff
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHolidaySyncer_Sync(t *testing.T) {
+	assert := assert.New(t)
+
+	var body string
+	var queries []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter,
+		r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	}))
+	defer ts.Close()
+
+	mb := New(Config{
+		HostURL:     ts.URL,
+		StaticToken: "staticToken",
+	})
+	store := NewMemoryHolidaySyncStore()
+
+	var reported []HolidayChange
+	var failCallback error
+	syncer := NewHolidaySyncer(mb, store, func(ctx context.Context, country string, changes []HolidayChange) error {
+		if failCallback != nil {
+			return failCallback
+		}
+		reported = append(reported, changes...)
+		return nil
+	})
+	ctx := context.Background()
+
+	body = `{"syncToken": "t1", "holidays": [
+		{"id": "a", "name": "Diwali", "date": "2024-11-01"},
+		{"id": "b", "name": "Holi", "date": "2024-03-25"}
+	]}`
+	changes, err := syncer.Sync(ctx, "IN")
+	assert.NoError(err)
+	assert.Len(changes, 2)
+	assert.Equal(HOLIDAY_ADDED, changes[0].Type)
+	assert.Equal("Holi", changes[0].New.Name)
+	assert.Equal("IN", changes[0].Country)
+	assert.Equal(changes, reported)
+
+	// The state is not saved when the callback fails, so the changes are reported again.
+	body = `{"syncToken": "t2", "holidays": [
+		{"id": "a", "name": "Deepavali", "date": "2024-11-01"},
+		{"id": "c", "name": "Pongal", "date": "2024-01-15"}
+	]}`
+	failCallback = errors.New("downstream unavailable")
+	_, err = syncer.Sync(ctx, "IN")
+	assert.Equal(failCallback, err)
+	failCallback = nil
+
+	reported = nil
+	changes, err = syncer.Sync(ctx, "IN")
+	assert.NoError(err)
+	assert.Len(changes, 3)
+	assert.Equal(HOLIDAY_ADDED, changes[0].Type)
+	assert.Equal("Pongal", changes[0].New.Name)
+	assert.Equal(HOLIDAY_REMOVED, changes[1].Type)
+	assert.Equal("Holi", changes[1].Old.Name)
+	assert.Equal(HOLIDAY_RENAMED, changes[2].Type)
+	assert.Equal("Diwali", changes[2].Old.Name)
+	assert.Equal("Deepavali", changes[2].New.Name)
+	assert.Equal(changes, reported)
+
+	state, err := store.Load(ctx, "IN")
+	assert.NoError(err)
+	assert.Equal("t2", state.SyncToken)
+	assert.Len(state.Holidays, 2)
+
+	body = `{"syncToken": "t3", "holidays": [
+		{"id": "a", "name": "Deepavali", "date": "2024-11-01"},
+		{"id": "c", "name": "Pongal", "date": "2024-01-14"}
+	]}`
+	changes, err = syncer.Sync(ctx, "IN")
+	assert.NoError(err)
+	assert.Len(changes, 1)
+	assert.Equal(HOLIDAY_MOVED, changes[0].Type)
+	assert.Equal("2024-01-15", changes[0].Old.Date)
+	assert.Equal("2024-01-14", changes[0].New.Date)
+
+	// Holidays missing from the calendar are reported as removed.
+	body = `{"syncToken": "t4", "holidays": [
+		{"id": "d", "name": "Republic Day", "date": "2024-01-26"}
+	]}`
+	changes, err = syncer.Sync(ctx, "IN")
+	assert.NoError(err)
+	if assert.Len(changes, 3) {
+		assert.Equal(HOLIDAY_REMOVED, changes[0].Type)
+		assert.Equal("Pongal", changes[0].Old.Name)
+		assert.Equal(HOLIDAY_ADDED, changes[1].Type)
+		assert.Equal("Republic Day", changes[1].New.Name)
+		assert.Equal(HOLIDAY_REMOVED, changes[2].Type)
+		assert.Equal("Deepavali", changes[2].Old.Name)
+	}
+	state, err = store.Load(ctx, "IN")
+	assert.NoError(err)
+	assert.Equal([]Holiday{{ID: "d", Name: "Republic Day", Date: "2024-01-26"}}, state.Holidays)
+
+	changes, err = syncer.Sync(ctx, "IN")
+	assert.NoError(err)
+	assert.Empty(changes)
+
+	for _, query := range queries {
+		assert.Empty(query, "the full calendar is requested")
+	}
+}
@@ -0,0 +1,159 @@
+package moviebuff
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Product identifier of iCalendar streams written by this package.
+const ICAL_PRODUCT_ID = "-//Real Image Media Technologies//Moviebuff SDK//EN"
+
+// Domain used to make the UIDs of iCalendar events globally unique.
+const icalUIDDomain = "moviebuff.com"
+
+// icalNow returns the time stamped on iCalendar events. It is replaced in tests.
+var icalNow = time.Now
+
+// WriteICalendar writes the holidays of the calendar as an iCalendar (RFC 5545) stream,
+// with one all-day event per holiday. Holidays with malformed dates are skipped.
+//
+// Event UIDs are derived from the holiday IDs, so that subscribers update events instead of duplicating them.
+func (c *Calendar) WriteICalendar(w io.Writer) error {
+	return writeICalendar(w, c.Name, c.TimeZone, []icalSource{{calendar: c}})
+}
+
+// WriteMergedICalendar writes the holidays of several countries' calendars as a single iCalendar stream named name.
+// calendars maps country codes like "IN" to their calendar. The summary of every event is prefixed
+// with its country code, like "IN: Diwali", and its UID includes the country code. Nil calendars are skipped.
+func WriteMergedICalendar(w io.Writer, name string, calendars map[string]*Calendar) error {
+	countries := make([]string, 0, len(calendars))
+	for country := range calendars {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+
+	sources := make([]icalSource, 0, len(countries))
+	timeZone := ""
+	for _, country := range countries {
+		c := calendars[country]
+		if c == nil {
+			continue
+		}
+		if len(sources) == 0 {
+			timeZone = c.TimeZone
+		} else if timeZone != c.TimeZone {
+			timeZone = ""
+		}
+		sources = append(sources, icalSource{country: country, calendar: c})
+	}
+	return writeICalendar(w, name, timeZone, sources)
+}
+
+type icalSource struct {
+	country  string
+	calendar *Calendar
+}
+
+func writeICalendar(w io.Writer, name, timeZone string, sources []icalSource) error {
+	iw := &icalWriter{w: bufio.NewWriter(w)}
+	stamp := icalNow().UTC().Format("20060102T150405Z")
+
+	iw.line("BEGIN:VCALENDAR")
+	iw.line("VERSION:2.0")
+	iw.line("PRODID:" + ICAL_PRODUCT_ID)
+	iw.line("CALSCALE:GREGORIAN")
+	iw.line("METHOD:PUBLISH")
+	if name != "" {
+		iw.line("X-WR-CALNAME:" + escapeICalText(name))
+	}
+	if timeZone != "" {
+		iw.line("X-WR-TIMEZONE:" + escapeICalText(timeZone))
+	}
+
+	for _, s := range sources {
+		for _, h := range s.calendar.Holidays {
+			date, err := time.Parse(holidayDateLayout, h.Date)
+			if err != nil {
+				continue
+			}
+
+			uid := h.ID
+			if uid == "" {
+				uid = h.Date + "-" + normalizeName(h.Name)
+			}
+			summary := h.Name
+			if s.country != "" {
+				uid = s.country + "-" + uid
+				summary = s.country + ": " + summary
+			}
+
+			iw.line("BEGIN:VEVENT")
+			iw.line("UID:" + escapeICalText(uid) + "@" + icalUIDDomain)
+			iw.line("DTSTAMP:" + stamp)
+			iw.line("DTSTART;VALUE=DATE:" + date.Format("20060102"))
+			iw.line("DTEND;VALUE=DATE:" + date.AddDate(0, 0, 1).Format("20060102"))
+			iw.line("SUMMARY:" + escapeICalText(summary))
+			if s.calendar.Name != "" {
+				iw.line("CATEGORIES:" + escapeICalText(s.calendar.Name))
+			}
+			iw.line("TRANSP:TRANSPARENT")
+			iw.line("END:VEVENT")
+		}
+	}
+
+	iw.line("END:VCALENDAR")
+	if iw.err != nil {
+		return iw.err
+	}
+	return iw.w.Flush()
+}
+
+// icalWriter writes content lines folded at 75 octets and terminated by CRLF, as required by RFC 5545.
+type icalWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (iw *icalWriter) line(s string) {
+	if iw.err != nil {
+		return
+	}
+
+	const limit = 75
+	for first := true; ; first = false {
+		max := limit
+		if !first {
+			// Continuation lines start with a space which counts towards the limit.
+			max = limit - 1
+			iw.w.WriteString(" ")
+		}
+		if len(s) <= max {
+			_, iw.err = iw.w.WriteString(s + "\r\n")
+			return
+		}
+
+		// Never split a multi-byte UTF-8 sequence.
+		cut := max
+		for cut > 0 && s[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		if _, iw.err = iw.w.WriteString(s[:cut] + "\r\n"); iw.err != nil {
+			return
+		}
+		s = s[cut:]
+	}
+}
+
+// escapeICalText escapes a TEXT value as defined by RFC 5545.
+func escapeICalText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
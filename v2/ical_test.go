@@ -0,0 +1,87 @@
+package moviebuff
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendar_WriteICalendar(t *testing.T) {
+	assert := assert.New(t)
+
+	defer func(now func() time.Time) { icalNow = now }(icalNow)
+	icalNow = func() time.Time { return time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC) }
+
+	c := &Calendar{
+		Name:     "Holidays in India",
+		TimeZone: "Asia/Calcutta",
+		Holidays: []Holiday{
+			{ID: "20181106_60o30d9h60o30c1g60o30dr565", Name: "Diwali, Deepavali", Date: "2018-11-06"},
+			{ID: "broken", Name: "Broken", Date: "not a date"},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	assert.NoError(c.WriteICalendar(buf))
+	assert.Equal(strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Real Image Media Technologies//Moviebuff SDK//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Holidays in India",
+		"X-WR-TIMEZONE:Asia/Calcutta",
+		"BEGIN:VEVENT",
+		"UID:20181106_60o30d9h60o30c1g60o30dr565@moviebuff.com",
+		"DTSTAMP:20240101T103000Z",
+		"DTSTART;VALUE=DATE:20181106",
+		"DTEND;VALUE=DATE:20181107",
+		"SUMMARY:Diwali\\, Deepavali",
+		"CATEGORIES:Holidays in India",
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n"), buf.String())
+}
+
+func TestWriteMergedICalendar(t *testing.T) {
+	assert := assert.New(t)
+
+	long := strings.Repeat("Ħoliday ", 20)
+	calendars := map[string]*Calendar{
+		"US": {Name: "Holidays in United States", TimeZone: "America/New_York",
+			Holidays: []Holiday{{ID: "1", Name: "Thanksgiving", Date: "2024-11-28"}}},
+		"IN": {Name: "Holidays in India", TimeZone: "Asia/Calcutta",
+			Holidays: []Holiday{{ID: "1", Name: long, Date: "2024-11-01"}}},
+	}
+
+	buf := new(bytes.Buffer)
+	assert.NoError(WriteMergedICalendar(buf, "Release Planning", calendars))
+	out := buf.String()
+
+	assert.Contains(out, "X-WR-CALNAME:Release Planning\r\n")
+	assert.NotContains(out, "X-WR-TIMEZONE")
+	assert.Contains(out, "UID:IN-1@moviebuff.com\r\n")
+	assert.Contains(out, "UID:US-1@moviebuff.com\r\n")
+	assert.Contains(out, "SUMMARY:US: Thanksgiving\r\n")
+	assert.True(strings.Index(out, "IN-1") < strings.Index(out, "US-1"))
+
+	for _, line := range strings.Split(out, "\r\n") {
+		assert.True(len(line) <= 75, line)
+	}
+	unfolded := strings.Replace(out, "\r\n ", "", -1)
+	assert.Contains(unfolded, "SUMMARY:IN: "+long+"\r\n")
+
+	// Nil calendars are skipped and do not reset the time zone.
+	buf.Reset()
+	assert.NoError(WriteMergedICalendar(buf, "Release Planning", map[string]*Calendar{
+		"AE": nil,
+		"US": calendars["US"],
+	}))
+	assert.Contains(buf.String(), "X-WR-TIMEZONE:America/New_York\r\n")
+	assert.Contains(buf.String(), "UID:US-1@moviebuff.com\r\n")
+}
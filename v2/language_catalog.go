@@ -0,0 +1,245 @@
+package moviebuff
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// LanguageCatalog resolves languages by UUID, ISO 639 codes, IANA tag or name.
+// Languages are fetched with GetLanguages on first use and cached. It is safe for concurrent use.
+type LanguageCatalog struct {
+	mb Moviebuff
+
+	mu        sync.Mutex
+	languages []Language
+	byKey     map[string]map[string]int
+}
+
+// Keys languages are indexed by
+const (
+	languageKeyUUID       = "uuid"
+	languageKeyISO639_1   = "iso639_1"
+	languageKeyISO639_2   = "iso639_2"
+	languageKeyIANA       = "iana"
+	languageKeyName       = "name"
+	languageKeyNativeName = "native_name"
+)
+
+// NewLanguageCatalog returns a catalog fetching languages from mb.
+func NewLanguageCatalog(mb Moviebuff) *LanguageCatalog {
+	return &LanguageCatalog{mb: mb}
+}
+
+// NewLanguageCatalogFrom returns a catalog of a copy of the given languages, which never fetches languages.
+func NewLanguageCatalogFrom(languages []Language) *LanguageCatalog {
+	c := new(LanguageCatalog)
+	c.index(copyLanguages(languages))
+	return c
+}
+
+// load fetches and indexes the languages unless they are cached.
+// A failed fetch is not cached, so that it is retried on the next lookup.
+func (c *LanguageCatalog) load(ctx context.Context) error {
+	c.mu.Lock()
+	loaded := c.byKey != nil
+	if !loaded && c.mb == nil {
+		c.index(nil)
+		loaded = true
+	}
+	c.mu.Unlock()
+
+	if loaded {
+		return nil
+	}
+	return c.fetch(ctx)
+}
+
+// fetch fetches the languages without holding the lock, so that lookups of cached languages are not blocked,
+// and replaces the cached languages only if the fetch succeeded.
+func (c *LanguageCatalog) fetch(ctx context.Context) error {
+	languages, err := c.mb.GetLanguages(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.index(copyLanguages(languages))
+	return nil
+}
+
+// Refresh fetches the languages again. The cached languages are kept if the fetch fails.
+func (c *LanguageCatalog) Refresh(ctx context.Context) error {
+	if c.mb == nil {
+		return nil
+	}
+	return c.fetch(ctx)
+}
+
+func normalizeLanguageKey(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// copyLanguages returns a copy of languages sharing no SpokenIn slice with them.
+func copyLanguages(languages []Language) []Language {
+	if languages == nil {
+		return nil
+	}
+	copied := make([]Language, len(languages))
+	for i, l := range languages {
+		copied[i] = copyLanguage(l)
+	}
+	return copied
+}
+
+func copyLanguage(l Language) Language {
+	l.SpokenIn = append([]string(nil), l.SpokenIn...)
+	return l
+}
+
+// index replaces the cached languages. It must be called with c.mu held.
+func (c *LanguageCatalog) index(languages []Language) {
+	byKey := map[string]map[string]int{}
+
+	add := func(key, value string, i int) {
+		value = normalizeLanguageKey(value)
+		if value == "" {
+			return
+		}
+		if byKey[key] == nil {
+			byKey[key] = map[string]int{}
+		}
+		if _, ok := byKey[key][value]; !ok {
+			byKey[key][value] = i
+		}
+	}
+
+	for i, l := range languages {
+		add(languageKeyUUID, l.UUID, i)
+		add(languageKeyISO639_1, l.ISO639_1, i)
+		add(languageKeyISO639_2, l.ISO639_2, i)
+		add(languageKeyIANA, l.IANA, i)
+		add(languageKeyName, l.Name, i)
+		add(languageKeyNativeName, l.NativeName, i)
+	}
+
+	c.languages = languages
+	c.byKey = byKey
+}
+
+// lookup returns the language indexed with value under any of the keys, tried in order.
+func (c *LanguageCatalog) lookup(ctx context.Context, value string, keys ...string) (*Language, error) {
+	if err := c.load(ctx); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value = normalizeLanguageKey(value)
+	for _, key := range keys {
+		if i, ok := c.byKey[key][value]; ok {
+			l := copyLanguage(c.languages[i])
+			return &l, nil
+		}
+	}
+	return nil, ErrResourceDoesNotExist
+}
+
+// Languages returns all the languages of the catalog.
+func (c *LanguageCatalog) Languages(ctx context.Context) ([]Language, error) {
+	if err := c.load(ctx); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return copyLanguages(c.languages), nil
+}
+
+// ByUUID returns the language with the given UUID.
+// Returns ErrResourceDoesNotExist if no language matches
+func (c *LanguageCatalog) ByUUID(ctx context.Context, uuid string) (*Language, error) {
+	return c.lookup(ctx, uuid, languageKeyUUID)
+}
+
+// ByISO639_1 returns the language with the given two letter ISO 639-1 code like "hi".
+// Returns ErrResourceDoesNotExist if no language matches
+func (c *LanguageCatalog) ByISO639_1(ctx context.Context, code string) (*Language, error) {
+	return c.lookup(ctx, code, languageKeyISO639_1)
+}
+
+// ByISO639_2 returns the language with the given three letter ISO 639-2 code like "hin".
+// Returns ErrResourceDoesNotExist if no language matches
+func (c *LanguageCatalog) ByISO639_2(ctx context.Context, code string) (*Language, error) {
+	return c.lookup(ctx, code, languageKeyISO639_2)
+}
+
+// ByIANA returns the language with the given IANA language tag like "hi".
+// Returns ErrResourceDoesNotExist if no language matches
+func (c *LanguageCatalog) ByIANA(ctx context.Context, tag string) (*Language, error) {
+	return c.lookup(ctx, tag, languageKeyIANA)
+}
+
+// ByName returns the language with the given English or native name, compared case insensitively.
+// Returns ErrResourceDoesNotExist if no language matches
+func (c *LanguageCatalog) ByName(ctx context.Context, name string) (*Language, error) {
+	return c.lookup(ctx, name, languageKeyName, languageKeyNativeName)
+}
+
+// Resolve returns the language matching s by any of UUID, ISO 639-1, ISO 639-2, IANA tag, English or native name.
+// It resolves the Language string of a movie to a full Language.
+// Returns ErrResourceDoesNotExist if no language matches
+func (c *LanguageCatalog) Resolve(ctx context.Context, s string) (*Language, error) {
+	return c.lookup(ctx, s, languageKeyUUID, languageKeyName, languageKeyNativeName,
+		languageKeyISO639_1, languageKeyISO639_2, languageKeyIANA)
+}
+
+// MovieLanguage returns the primary language of a movie, resolved from its language data or its language.
+// Returns ErrResourceDoesNotExist if no language matches
+func (c *LanguageCatalog) MovieLanguage(ctx context.Context, m *Movie) (*Language, error) {
+	if m.LanguageData.UUID != "" {
+		l, err := c.ByUUID(ctx, m.LanguageData.UUID)
+		if err != ErrResourceDoesNotExist {
+			return l, err
+		}
+	}
+	if m.LanguageData.Name != "" {
+		l, err := c.ByName(ctx, m.LanguageData.Name)
+		if err != ErrResourceDoesNotExist {
+			return l, err
+		}
+	}
+	return c.Resolve(ctx, m.Language)
+}
+
+// SpokenIn returns the languages spoken in the given country, sorted by name.
+// Country is compared case insensitively against the SpokenIn values of the languages.
+func (c *LanguageCatalog) SpokenIn(ctx context.Context, country string) ([]Language, error) {
+	if err := c.load(ctx); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	country = normalizeLanguageKey(country)
+	var languages []Language
+	for _, l := range c.languages {
+		for _, spokenIn := range l.SpokenIn {
+			if normalizeLanguageKey(spokenIn) == country {
+				languages = append(languages, copyLanguage(l))
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].Name < languages[j].Name
+	})
+	return languages, nil
+}
@@ -0,0 +1,173 @@
+package moviebuff
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguageCatalog(t *testing.T) {
+	assert := assert.New(t)
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter,
+		r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[
+			{"uuid": "hi-uuid", "name": "Hindi", "iso639_1": "hi", "iso639_2": "hin", "iana": "hi", "native_name": "हिन्दी", "spoken_in": ["IN", "FJ"]},
+			{"uuid": "ta-uuid", "name": "Tamil", "iso639_1": "ta", "iso639_2": "tam", "iana": "ta", "native_name": "தமிழ்", "spoken_in": ["IN", "LK", "SG"]},
+			{"uuid": "en-uuid", "name": "English", "iso639_1": "en", "iso639_2": "eng", "iana": "en", "native_name": "English", "spoken_in": ["GB", "IN", "US"]}
+		]`))
+	}))
+	defer ts.Close()
+
+	c := NewLanguageCatalog(New(Config{
+		HostURL:     ts.URL,
+		StaticToken: "staticToken",
+	}))
+	ctx := context.Background()
+
+	l, err := c.ByISO639_1(ctx, "TA")
+	assert.NoError(err)
+	assert.Equal("Tamil", l.Name)
+
+	l, err = c.ByISO639_2(ctx, "hin")
+	assert.NoError(err)
+	assert.Equal("Hindi", l.Name)
+
+	l, err = c.ByIANA(ctx, "en")
+	assert.NoError(err)
+	assert.Equal("English", l.Name)
+
+	l, err = c.ByName(ctx, "தமிழ்")
+	assert.NoError(err)
+	assert.Equal("ta-uuid", l.UUID)
+
+	l, err = c.Resolve(ctx, " hindi ")
+	assert.NoError(err)
+	assert.Equal("hi-uuid", l.UUID)
+
+	_, err = c.ByUUID(ctx, "unknown")
+	assert.Equal(ErrResourceDoesNotExist, err)
+
+	m := &Movie{Language: "Tamil"}
+	l, err = c.MovieLanguage(ctx, m)
+	assert.NoError(err)
+	assert.Equal("ta-uuid", l.UUID)
+	m.LanguageData.UUID = "hi-uuid"
+	l, err = c.MovieLanguage(ctx, m)
+	assert.NoError(err)
+	assert.Equal("Hindi", l.Name)
+
+	languages, err := c.SpokenIn(ctx, "in")
+	assert.NoError(err)
+	assert.Len(languages, 3)
+	assert.Equal("English", languages[0].Name)
+
+	languages, err = c.SpokenIn(ctx, "LK")
+	assert.NoError(err)
+	assert.Len(languages, 1)
+
+	assert.Equal(1, requests, "languages are fetched once")
+
+	assert.NoError(c.Refresh(ctx))
+	assert.Equal(2, requests)
+}
+
+func TestLanguageCatalog_Error(t *testing.T) {
+	assert := assert.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter,
+		r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	c := NewLanguageCatalog(New(Config{
+		HostURL:     ts.URL,
+		StaticToken: "staticToken",
+	}))
+
+	_, err := c.ByName(context.Background(), "Hindi")
+	assert.Equal(ErrInvalidToken, err)
+
+	c = NewLanguageCatalogFrom([]Language{{UUID: "hi-uuid", Name: "Hindi"}})
+	l, err := c.ByName(context.Background(), "HINDI")
+	assert.NoError(err)
+	assert.Equal("hi-uuid", l.UUID)
+}
+
+func TestLanguageCatalog_Refresh(t *testing.T) {
+	assert := assert.New(t)
+
+	var mu sync.Mutex
+	status := http.StatusOK
+	var blocked, release chan struct{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter,
+		r *http.Request) {
+		mu.Lock()
+		s, b, rel := status, blocked, release
+		mu.Unlock()
+		if b != nil {
+			b <- struct{}{}
+			<-rel
+		}
+		w.WriteHeader(s)
+		w.Write([]byte(`[{"uuid": "hi-uuid", "name": "Hindi", "spoken_in": ["IN"]}]`))
+	}))
+	defer ts.Close()
+
+	c := NewLanguageCatalog(New(Config{
+		HostURL:     ts.URL,
+		StaticToken: "staticToken",
+	}))
+	ctx := context.Background()
+
+	l, err := c.ByName(ctx, "Hindi")
+	assert.NoError(err)
+	l.SpokenIn[0] = "changed"
+	languages, err := c.SpokenIn(ctx, "IN")
+	assert.NoError(err)
+	assert.Len(languages, 1, "returned languages do not share their data with the cache")
+
+	// Lookups are not blocked while languages are fetched again.
+	mu.Lock()
+	blocked, release = make(chan struct{}), make(chan struct{})
+	mu.Unlock()
+	done := make(chan error)
+	go func() {
+		done <- c.Refresh(ctx)
+	}()
+	<-blocked
+	_, err = c.ByName(ctx, "Hindi")
+	assert.NoError(err)
+	close(release)
+	assert.NoError(<-done)
+
+	// A failed refresh keeps the cached languages.
+	mu.Lock()
+	status, blocked = http.StatusInternalServerError, nil
+	mu.Unlock()
+	assert.Error(c.Refresh(ctx))
+	_, err = c.ByName(ctx, "Hindi")
+	assert.NoError(err)
+}
+
+func TestNewLanguageCatalogFrom(t *testing.T) {
+	assert := assert.New(t)
+
+	languages := []Language{{UUID: "hi-uuid", Name: "Hindi", SpokenIn: []string{"IN"}}}
+	c := NewLanguageCatalogFrom(languages)
+	languages[0].Name = "changed"
+	languages[0].SpokenIn[0] = "changed"
+
+	l, err := c.ByUUID(context.Background(), "hi-uuid")
+	assert.NoError(err)
+	assert.Equal("Hindi", l.Name)
+	assert.Equal([]string{"IN"}, l.SpokenIn)
+}
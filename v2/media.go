@@ -0,0 +1,178 @@
+package moviebuff
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Video type of a trailer.
+const VIDEO_TYPE_TRAILER = "trailer"
+
+// Image contains the details of a poster or a still.
+type Image struct {
+	Featured bool   `json:"featured"`
+	URL      string `json:"url"`
+	Key      string `json:"key"`
+	Caption  string `json:"caption"`
+	Type     string `json:"type"`
+}
+
+// Video contains the details of a trailer or any other video.
+type Video struct {
+	Featured  bool   `json:"featured"`
+	URL       string `json:"url"`
+	EmbedURL  string `json:"embedUrl"`
+	Key       string `json:"key"`
+	Caption   string `json:"caption"`
+	Thumbnail string `json:"thumbnail"`
+	Type      string `json:"type"`
+}
+
+// FeaturedImage returns the first featured image, or the first image if none is featured.
+// ok is false if there are no images
+func FeaturedImage(images []Image) (image Image, ok bool) {
+	for _, i := range images {
+		if i.Featured {
+			return i, true
+		}
+	}
+	if len(images) > 0 {
+		return images[0], true
+	}
+	return Image{}, false
+}
+
+// FeaturedVideo returns the first featured video, or the first video if none is featured.
+// ok is false if there are no videos
+func FeaturedVideo(videos []Video) (video Video, ok bool) {
+	for _, v := range videos {
+		if v.Featured {
+			return v, true
+		}
+	}
+	if len(videos) > 0 {
+		return videos[0], true
+	}
+	return Video{}, false
+}
+
+// GroupImagesByType returns the images grouped by their type, keeping their order.
+// Type is lower cased.
+func GroupImagesByType(images []Image) map[string][]Image {
+	groups := map[string][]Image{}
+	for _, i := range images {
+		t := strings.ToLower(i.Type)
+		groups[t] = append(groups[t], i)
+	}
+	return groups
+}
+
+// GroupVideosByType returns the videos grouped by their type, keeping their order.
+// Type is lower cased.
+func GroupVideosByType(videos []Video) map[string][]Video {
+	groups := map[string][]Video{}
+	for _, v := range videos {
+		t := strings.ToLower(v.Type)
+		groups[t] = append(groups[t], v)
+	}
+	return groups
+}
+
+// primaryPoster picks the poster of a resource. The poster field is used when available,
+// followed by the featured poster and the first poster.
+func primaryPoster(poster string, posters []Image) (image Image, ok bool) {
+	if poster != "" {
+		for _, p := range posters {
+			if p.URL == poster {
+				return p, true
+			}
+		}
+		return Image{URL: poster}, true
+	}
+	return FeaturedImage(posters)
+}
+
+// GetPrimaryPoster returns the main poster of the movie.
+// ok is false if the movie has no poster
+func (m *Movie) GetPrimaryPoster() (poster Image, ok bool) {
+	return primaryPoster(m.Poster, m.Posters)
+}
+
+// GetFeaturedStill returns the featured still of the movie, or its first still.
+// ok is false if the movie has no stills
+func (m *Movie) GetFeaturedStill() (still Image, ok bool) {
+	return FeaturedImage(m.Stills)
+}
+
+// GetFeaturedVideo returns the featured video of the movie, or its first video.
+// ok is false if the movie has no videos
+func (m *Movie) GetFeaturedVideo() (video Video, ok bool) {
+	return FeaturedVideo(m.Videos)
+}
+
+// GetTrailer returns the trailer of the movie. The trailer field is used when available,
+// followed by the featured trailer and the first trailer among the videos.
+// ok is false if the movie has no trailer
+func (m *Movie) GetTrailer() (trailer Video, ok bool) {
+	if m.Trailer.URL != "" || m.Trailer.EmbedURL != "" {
+		return m.Trailer, true
+	}
+	return FeaturedVideo(GroupVideosByType(m.Videos)[VIDEO_TYPE_TRAILER])
+}
+
+// GetPrimaryPoster returns the main profile photo of the person.
+// ok is false if the person has no poster
+func (p *Person) GetPrimaryPoster() (poster Image, ok bool) {
+	return primaryPoster(p.Poster, p.Posters)
+}
+
+// GetFeaturedStill returns the featured still of the person, or their first still.
+// ok is false if the person has no stills
+func (p *Person) GetFeaturedStill() (still Image, ok bool) {
+	return FeaturedImage(p.Stills)
+}
+
+// GetFeaturedVideo returns the featured video of the person, or their first video.
+// ok is false if the person has no videos
+func (p *Person) GetFeaturedVideo() (video Video, ok bool) {
+	return FeaturedVideo(p.Videos)
+}
+
+// GetPrimaryPoster returns the main poster of the entity.
+// ok is false if the entity has no poster
+func (e *Entity) GetPrimaryPoster() (poster Image, ok bool) {
+	return primaryPoster(e.Poster, e.Posters)
+}
+
+// GetFeaturedStill returns the featured still of the entity, or its first still.
+// ok is false if the entity has no stills
+func (e *Entity) GetFeaturedStill() (still Image, ok bool) {
+	return FeaturedImage(e.Stills)
+}
+
+// GetFeaturedVideo returns the featured video of the entity, or its first video.
+// ok is false if the entity has no videos
+func (e *Entity) GetFeaturedVideo() (video Video, ok bool) {
+	return FeaturedVideo(e.Videos)
+}
+
+// ImageCDN builds sized image URLs from the Key of an image, for CDNs which serve image variants.
+//
+// URLTemplate is the URL of a variant with the placeholders {key}, {width} and {height},
+// like "https://images.example.com/{width}x{height}/{key}". A zero width or height is passed to the CDN as is.
+type ImageCDN struct {
+	URLTemplate string
+}
+
+// URL returns the URL of the image resized to width and height.
+// The original URL of the image is returned if the CDN is not configured or the image has no key.
+func (c ImageCDN) URL(image Image, width, height int) string {
+	if c.URLTemplate == "" || image.Key == "" {
+		return image.URL
+	}
+	return strings.NewReplacer(
+		"{key}", image.Key,
+		"{width}", strconv.Itoa(width),
+		"{height}", strconv.Itoa(height),
+	).Replace(c.URLTemplate)
+}
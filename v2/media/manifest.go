@@ -0,0 +1,108 @@
+package media
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Name of the manifest file in the directory of a Downloader.
+const MANIFEST_FILE = "manifest.json"
+
+// Manifest lists the assets stored in a directory.
+type Manifest struct {
+	Assets []Asset `json:"assets"`
+}
+
+// LoadManifest reads the manifest of dir. An empty manifest is returned if dir has none yet.
+func LoadManifest(dir string) (*Manifest, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dir, MANIFEST_FILE))
+	if os.IsNotExist(err) {
+		return new(Manifest), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := new(Manifest)
+	err = json.Unmarshal(content, manifest)
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Save writes the manifest to dir.
+func (m *Manifest) Save(dir string) error {
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	tmp := filepath.Join(dir, MANIFEST_FILE+".tmp")
+	if err := ioutil.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(dir, MANIFEST_FILE))
+}
+
+// Add adds assets to the manifest, replacing any asset of the same resource, kind and URL.
+func (m *Manifest) Add(assets ...Asset) {
+	for _, a := range assets {
+		replaced := false
+		for i, existing := range m.Assets {
+			if existing.ResourceUUID == a.ResourceUUID && existing.Kind == a.Kind && existing.URL == a.URL {
+				m.Assets[i] = a
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			m.Assets = append(m.Assets, a)
+		}
+	}
+}
+
+// ForResource returns the assets of the resource with the given UUID.
+func (m *Manifest) ForResource(uuid string) []Asset {
+	var assets []Asset
+	for _, a := range m.Assets {
+		if a.ResourceUUID == uuid {
+			assets = append(assets, a)
+		}
+	}
+	return assets
+}
+
+// ByKey returns the asset with the given media key.
+// ok is false if no asset has the key
+func (m *Manifest) ByKey(key string) (asset Asset, ok bool) {
+	for _, a := range m.Assets {
+		if key != "" && a.Key == key {
+			return a, true
+		}
+	}
+	return Asset{}, false
+}
+
+// record adds assets to the manifest of the directory.
+func (d *Downloader) record(assets []Asset) error {
+	if len(assets) == 0 {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	manifest, err := LoadManifest(d.Dir)
+	if err != nil {
+		return err
+	}
+	manifest.Add(assets...)
+	return manifest.Save(d.Dir)
+}
@@ -0,0 +1,378 @@
+// Package media downloads posters and stills of Moviebuff resources for offline use.
+//
+// Images are stored content-addressed on disk, so an image shared by several resources is stored once.
+// Thumbnails are generated for JPEG and PNG images at the configured sizes,
+// and a manifest links every stored image back to the UUID of its resource and its media key.
+package media
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+)
+
+// Kind of images downloaded
+const (
+	KIND_POSTER = "poster"
+	KIND_STILL  = "still"
+)
+
+// Type of resources images are downloaded for
+const (
+	RESOURCE_MOVIE  = "movie"
+	RESOURCE_PERSON = "person"
+	RESOURCE_ENTITY = "entity"
+)
+
+// Default limits of a Downloader
+const (
+	DEFAULT_MAX_SIZE   = 20 << 20
+	DEFAULT_MAX_PIXELS = 50000000
+)
+
+var (
+	ErrTooLarge      = errors.New("media: image larger than the maximum size")
+	ErrTooManyPixels = errors.New("media: image has more pixels than the maximum")
+)
+
+// Config of a Downloader.
+type Config struct {
+	// Dir is the directory images, thumbnails and the manifest are stored in.
+	Dir string
+
+	// Client used to download images. http.DefaultClient is used if nil.
+	Client *http.Client
+
+	// Concurrency is the maximum number of images downloaded at once. Defaults to 4.
+	Concurrency int
+
+	// ThumbnailSizes are the boxes thumbnails are generated to fit in.
+	ThumbnailSizes []Size
+
+	// MaxSize is the maximum size of an image in bytes. Defaults to DEFAULT_MAX_SIZE.
+	MaxSize int64
+
+	// MaxPixels is the maximum number of pixels of an image thumbnails are generated for,
+	// checked before the image is decoded. Defaults to DEFAULT_MAX_PIXELS.
+	MaxPixels int
+}
+
+// Request is an image to download for a resource.
+type Request struct {
+	ResourceUUID string
+	ResourceType string
+	Kind         string
+	Image        moviebuff.Image
+}
+
+// Asset is an image stored on disk.
+type Asset struct {
+	ResourceUUID string      `json:"resourceUuid"`
+	ResourceType string      `json:"resourceType"`
+	Kind         string      `json:"kind"`
+	Key          string      `json:"key"`
+	URL          string      `json:"url"`
+	Caption      string      `json:"caption,omitempty"`
+	Featured     bool        `json:"featured,omitempty"`
+	SHA256       string      `json:"sha256"`
+	ContentType  string      `json:"contentType"`
+	Path         string      `json:"path"`
+	Thumbnails   []Thumbnail `json:"thumbnails,omitempty"`
+}
+
+// AssetError is the error of a request which could not be downloaded.
+type AssetError struct {
+	Request Request
+	Err     error
+}
+
+func (e *AssetError) Error() string {
+	return fmt.Sprintf("media: %s %s of %s %s: %v", e.Request.Kind, e.Request.Image.URL,
+		e.Request.ResourceType, e.Request.ResourceUUID, e.Err)
+}
+
+func (e *AssetError) Unwrap() error {
+	return e.Err
+}
+
+// Errors lists the requests which could not be downloaded.
+type Errors []*AssetError
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ManifestError is returned when downloaded assets could not be recorded in the manifest.
+// Failed lists the requests which could not be downloaded, if any.
+type ManifestError struct {
+	Err    error
+	Failed Errors
+}
+
+func (e *ManifestError) Error() string {
+	msg := fmt.Sprintf("media: recording manifest: %v", e.Err)
+	if len(e.Failed) > 0 {
+		msg += "; " + e.Failed.Error()
+	}
+	return msg
+}
+
+func (e *ManifestError) Unwrap() error {
+	return e.Err
+}
+
+// As sets target to the failed requests when it is an *Errors, so that errors.As finds them.
+func (e *ManifestError) As(target interface{}) bool {
+	if failed, ok := target.(*Errors); ok && len(e.Failed) > 0 {
+		*failed = e.Failed
+		return true
+	}
+	return false
+}
+
+// Downloader downloads images of Moviebuff resources.
+type Downloader struct {
+	Config
+
+	// mu serialises updates of the manifest.
+	mu sync.Mutex
+}
+
+// New returns a Downloader.
+func New(config Config) *Downloader {
+	if config.Client == nil {
+		config.Client = http.DefaultClient
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = 4
+	}
+	if config.MaxSize <= 0 {
+		config.MaxSize = DEFAULT_MAX_SIZE
+	}
+	if config.MaxPixels <= 0 {
+		config.MaxPixels = DEFAULT_MAX_PIXELS
+	}
+	return &Downloader{
+		Config: config,
+	}
+}
+
+// MovieRequests returns the requests for the posters and stills of a movie.
+func MovieRequests(m *moviebuff.Movie) []Request {
+	return requests(m.UUID, RESOURCE_MOVIE, m.Poster, m.Posters, m.Stills)
+}
+
+// PersonRequests returns the requests for the posters and stills of a person.
+func PersonRequests(p *moviebuff.Person) []Request {
+	return requests(p.UUID, RESOURCE_PERSON, p.Poster, p.Posters, p.Stills)
+}
+
+// EntityRequests returns the requests for the posters and stills of an entity.
+func EntityRequests(e *moviebuff.Entity) []Request {
+	return requests(e.UUID, RESOURCE_ENTITY, e.Poster, e.Posters, e.Stills)
+}
+
+func requests(uuid, resourceType, poster string, posters, stills []moviebuff.Image) []Request {
+	var reqs []Request
+	listed := false
+	for _, p := range posters {
+		listed = listed || p.URL == poster
+		reqs = append(reqs, Request{ResourceUUID: uuid, ResourceType: resourceType, Kind: KIND_POSTER, Image: p})
+	}
+	if poster != "" && !listed {
+		reqs = append(reqs, Request{ResourceUUID: uuid, ResourceType: resourceType, Kind: KIND_POSTER,
+			Image: moviebuff.Image{URL: poster}})
+	}
+	for _, s := range stills {
+		reqs = append(reqs, Request{ResourceUUID: uuid, ResourceType: resourceType, Kind: KIND_STILL, Image: s})
+	}
+	return reqs
+}
+
+// DownloadMovie downloads the posters and stills of a movie. See Download.
+func (d *Downloader) DownloadMovie(ctx context.Context, m *moviebuff.Movie) ([]Asset, error) {
+	return d.Download(ctx, MovieRequests(m))
+}
+
+// DownloadPerson downloads the posters and stills of a person. See Download.
+func (d *Downloader) DownloadPerson(ctx context.Context, p *moviebuff.Person) ([]Asset, error) {
+	return d.Download(ctx, PersonRequests(p))
+}
+
+// DownloadEntity downloads the posters and stills of an entity. See Download.
+func (d *Downloader) DownloadEntity(ctx context.Context, e *moviebuff.Entity) ([]Asset, error) {
+	return d.Download(ctx, EntityRequests(e))
+}
+
+// Download downloads the requested images concurrently, generates their thumbnails
+// and records them in the manifest of the directory.
+//
+// Assets are returned in the order of the requests for every request that succeeded.
+// If any request failed, the returned error is of type Errors.
+// If the manifest could not be updated, it is a *ManifestError holding the failed requests instead.
+func (d *Downloader) Download(ctx context.Context, reqs []Request) ([]Asset, error) {
+	assets := make([]*Asset, len(reqs))
+	errs := make([]*AssetError, len(reqs))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < d.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				asset, err := d.download(ctx, reqs[i])
+				if err != nil {
+					errs[i] = &AssetError{Request: reqs[i], Err: err}
+					continue
+				}
+				assets[i] = asset
+			}
+		}()
+	}
+
+	for i := range reqs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var downloaded []Asset
+	var failed Errors
+	for i := range reqs {
+		if errs[i] != nil {
+			failed = append(failed, errs[i])
+			continue
+		}
+		downloaded = append(downloaded, *assets[i])
+	}
+
+	if err := d.record(downloaded); err != nil {
+		return downloaded, &ManifestError{Err: err, Failed: failed}
+	}
+	if len(failed) > 0 {
+		return downloaded, failed
+	}
+	return downloaded, nil
+}
+
+// download fetches a single image, stores it and generates its thumbnails.
+func (d *Downloader) download(ctx context.Context, req Request) (*Asset, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, req.Image.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := d.Client.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, moviebuff.ErrResponseNotReceived
+	}
+
+	if res.ContentLength > d.MaxSize {
+		return nil, ErrTooLarge
+	}
+	content, err := ioutil.ReadAll(io.LimitReader(res.Body, d.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > d.MaxSize {
+		return nil, ErrTooLarge
+	}
+
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+	contentType := http.DetectContentType(content)
+
+	thumbnails, err := d.thumbnails(hash, contentType, content)
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join("objects", hash[:2], hash+extension(contentType))
+	if err := writeFile(filepath.Join(d.Dir, path), content); err != nil {
+		return nil, err
+	}
+
+	return &Asset{
+		ResourceUUID: req.ResourceUUID,
+		ResourceType: req.ResourceType,
+		Kind:         req.Kind,
+		Key:          req.Image.Key,
+		URL:          req.Image.URL,
+		Caption:      req.Image.Caption,
+		Featured:     req.Image.Featured,
+		SHA256:       hash,
+		ContentType:  contentType,
+		Path:         filepath.ToSlash(path),
+		Thumbnails:   thumbnails,
+	}, nil
+}
+
+// extension returns the file extension for a detected content type.
+func extension(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	}
+	return ""
+}
+
+// writeFile writes content to path unless it already exists.
+// The content is written to a temporary file first so that a partial file is never left at path.
+func writeFile(path string, content []byte) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,226 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func pngImage(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDownloader_DownloadMovie(t *testing.T) {
+	assert := assert.New(t)
+
+	poster := pngImage(t, 200, 300)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/poster.png", "/copy.png":
+			w.Write(poster)
+		case "/still.txt":
+			w.Write([]byte("not an image"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "media")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	d := New(Config{
+		Dir:            dir,
+		Concurrency:    2,
+		ThumbnailSizes: []Size{{Width: 100}, {Width: 100, Height: 100}},
+	})
+
+	m := &moviebuff.Movie{
+		UUID:   "movie-uuid",
+		Poster: ts.URL + "/poster.png",
+		Posters: []moviebuff.Image{
+			{URL: ts.URL + "/poster.png", Key: "poster-key", Featured: true},
+			{URL: ts.URL + "/copy.png", Key: "copy-key"},
+		},
+		Stills: []moviebuff.Image{
+			{URL: ts.URL + "/still.txt", Key: "still-key"},
+			{URL: ts.URL + "/missing.png", Key: "missing-key"},
+		},
+	}
+
+	assets, err := d.DownloadMovie(context.Background(), m)
+	var failed Errors
+	assert.True(errors.As(err, &failed))
+	assert.Len(failed, 1)
+	assert.Equal("missing-key", failed[0].Request.Image.Key)
+	assert.Equal(moviebuff.ErrResponseNotReceived, errors.Unwrap(failed[0]))
+
+	assert.Len(assets, 3)
+	assert.Equal("poster-key", assets[0].Key)
+	assert.Equal(KIND_POSTER, assets[0].Kind)
+	assert.Equal(RESOURCE_MOVIE, assets[0].ResourceType)
+	assert.Equal("image/png", assets[0].ContentType)
+	assert.Equal(assets[0].Path, assets[1].Path, "identical images are stored once")
+	assert.Equal([]Thumbnail{
+		{Size: Size{Width: 100, Height: 150}, Path: assets[0].Thumbnails[0].Path},
+		{Size: Size{Width: 67, Height: 100}, Path: assets[0].Thumbnails[1].Path},
+	}, assets[0].Thumbnails)
+	assert.Empty(assets[2].Thumbnails)
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, assets[0].Path))
+	assert.NoError(err)
+	assert.Equal(poster, content)
+
+	f, err := os.Open(filepath.Join(dir, assets[0].Thumbnails[1].Path))
+	assert.NoError(err)
+	defer f.Close()
+	thumbnail, format, err := image.Decode(f)
+	assert.NoError(err)
+	assert.Equal("jpeg", format)
+	assert.Equal(image.Rect(0, 0, 67, 100), thumbnail.Bounds())
+
+	manifest, err := LoadManifest(dir)
+	assert.NoError(err)
+	assert.Len(manifest.ForResource("movie-uuid"), 3)
+	asset, ok := manifest.ByKey("still-key")
+	assert.True(ok)
+	assert.Equal(KIND_STILL, asset.Kind)
+
+	_, err = d.DownloadMovie(context.Background(), &moviebuff.Movie{
+		UUID:    "movie-uuid",
+		Posters: m.Posters,
+	})
+	assert.NoError(err)
+	manifest, err = LoadManifest(dir)
+	assert.NoError(err)
+	assert.Len(manifest.Assets, 3, "downloading again replaces existing entries")
+}
+
+func TestDownloader_Limits(t *testing.T) {
+	poster := pngImage(t, 200, 300)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chunked.png" {
+			// Flushing before writing the body drops the Content-Length header.
+			w.(http.Flusher).Flush()
+		}
+		w.Write(poster)
+	}))
+	defer ts.Close()
+
+	var testCases = []struct {
+		desc        string
+		config      Config
+		url         string
+		expectedErr error
+	}{
+		{
+			desc:        "larger than the maximum size",
+			config:      Config{MaxSize: 100},
+			url:         "/poster.png",
+			expectedErr: ErrTooLarge,
+		}, {
+			desc:        "larger than the maximum size without content length",
+			config:      Config{MaxSize: 100},
+			url:         "/chunked.png",
+			expectedErr: ErrTooLarge,
+		}, {
+			desc:        "more pixels than the maximum",
+			config:      Config{MaxPixels: 200*300 - 1, ThumbnailSizes: []Size{{Width: 100}}},
+			url:         "/poster.png",
+			expectedErr: ErrTooManyPixels,
+		}, {
+			desc:   "within limits",
+			config: Config{MaxSize: int64(len(poster)), MaxPixels: 200 * 300, ThumbnailSizes: []Size{{Width: 100}}},
+			url:    "/poster.png",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			dir, err := ioutil.TempDir("", "media")
+			assert.NoError(err)
+			defer os.RemoveAll(dir)
+
+			testCase.config.Dir = dir
+			d := New(testCase.config)
+			assets, err := d.Download(context.Background(), []Request{{
+				ResourceUUID: "movie-uuid",
+				ResourceType: RESOURCE_MOVIE,
+				Kind:         KIND_POSTER,
+				Image:        moviebuff.Image{URL: ts.URL + testCase.url},
+			}})
+			if testCase.expectedErr != nil {
+				var failed Errors
+				if assert.True(errors.As(err, &failed)) {
+					assert.Equal(testCase.expectedErr, failed[0].Err)
+				}
+				assert.Empty(assets)
+				return
+			}
+			assert.NoError(err)
+			assert.Len(assets, 1)
+		})
+	}
+}
+
+func TestDownloader_ManifestError(t *testing.T) {
+	assert := assert.New(t)
+
+	poster := pngImage(t, 20, 30)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/poster.png" {
+			w.Write(poster)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "media")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, MANIFEST_FILE), []byte("{"), 0644))
+
+	d := New(Config{Dir: dir})
+	assets, err := d.Download(context.Background(), []Request{
+		{ResourceUUID: "movie-uuid", ResourceType: RESOURCE_MOVIE, Kind: KIND_POSTER, Image: moviebuff.Image{URL: ts.URL + "/poster.png"}},
+		{ResourceUUID: "movie-uuid", ResourceType: RESOURCE_MOVIE, Kind: KIND_STILL, Image: moviebuff.Image{URL: ts.URL + "/missing.png"}},
+	})
+	assert.Len(assets, 1)
+
+	var manifestErr *ManifestError
+	if assert.True(errors.As(err, &manifestErr)) {
+		assert.Error(manifestErr.Err)
+	}
+	var failed Errors
+	if assert.True(errors.As(err, &failed)) {
+		assert.Len(failed, 1)
+		assert.Equal(moviebuff.ErrResponseNotReceived, failed[0].Err)
+	}
+}
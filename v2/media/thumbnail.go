@@ -0,0 +1,129 @@
+package media
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"path/filepath"
+)
+
+// Size is a box a thumbnail is scaled down to fit in, keeping its aspect ratio.
+// A zero Width or Height leaves that dimension unconstrained.
+type Size struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Thumbnail is a scaled down copy of an asset stored as JPEG.
+type Thumbnail struct {
+	Size
+	Path string `json:"path"`
+}
+
+// thumbnails generates the configured thumbnails of an image.
+// Images other than JPEG and PNG have no thumbnails, images with more than MaxPixels pixels are rejected.
+func (d *Downloader) thumbnails(hash, contentType string, content []byte) ([]Thumbnail, error) {
+	if len(d.ThumbnailSizes) == 0 || (contentType != "image/jpeg" && contentType != "image/png") {
+		return nil, nil
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	if int64(config.Width)*int64(config.Height) > int64(d.MaxPixels) {
+		return nil, ErrTooManyPixels
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	var thumbnails []Thumbnail
+	for _, size := range d.ThumbnailSizes {
+		width, height := fit(src.Bounds().Dx(), src.Bounds().Dy(), size)
+		if width == 0 || height == 0 {
+			continue
+		}
+
+		path := filepath.Join("thumbnails", hash[:2], fmt.Sprintf("%s_%dx%d.jpg", hash, size.Width, size.Height))
+
+		buf := new(bytes.Buffer)
+		if err := jpeg.Encode(buf, scale(src, width, height), &jpeg.Options{Quality: 85}); err != nil {
+			return nil, err
+		}
+		if err := writeFile(filepath.Join(d.Dir, path), buf.Bytes()); err != nil {
+			return nil, err
+		}
+
+		thumbnails = append(thumbnails, Thumbnail{
+			Size: Size{Width: width, Height: height},
+			Path: filepath.ToSlash(path),
+		})
+	}
+	return thumbnails, nil
+}
+
+// fit returns the dimensions of an image of width and height scaled down to fit in size.
+// Images are never scaled up.
+func fit(width, height int, size Size) (int, int) {
+	scaled := 1.0
+	if size.Width > 0 && width > size.Width {
+		scaled = float64(size.Width) / float64(width)
+	}
+	if size.Height > 0 && float64(height)*scaled > float64(size.Height) {
+		scaled = float64(size.Height) / float64(height)
+	}
+
+	w, h := int(float64(width)*scaled+0.5), int(float64(height)*scaled+0.5)
+	if w == 0 && width > 0 {
+		w = 1
+	}
+	if h == 0 && height > 0 {
+		h = 1
+	}
+	return w, h
+}
+
+// scale resizes src to width and height by averaging the source pixels covered by every destination pixel.
+func scale(src image.Image, width, height int) image.Image {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	b := src.Bounds()
+
+	for y := 0; y < height; y++ {
+		y0 := b.Min.Y + y*b.Dy()/height
+		y1 := b.Min.Y + (y+1)*b.Dy()/height
+		if y1 == y0 {
+			y1 = y0 + 1
+		}
+
+		for x := 0; x < width; x++ {
+			x0 := b.Min.X + x*b.Dx()/width
+			x1 := b.Min.X + (x+1)*b.Dx()/width
+			if x1 == x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(bl / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
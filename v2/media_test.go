@@ -0,0 +1,100 @@
+package moviebuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovie_GetPrimaryPoster(t *testing.T) {
+	posters := []Image{
+		{URL: "first.jpg", Key: "first", Type: "Poster"},
+		{URL: "featured.jpg", Key: "featured", Featured: true, Type: "Poster"},
+		{URL: "main.jpg", Key: "main", Type: "Banner"},
+	}
+
+	var testCases = []struct {
+		desc           string
+		movie          *Movie
+		expectedPoster Image
+		expectedOK     bool
+	}{
+		{
+			desc:           "poster field listed among posters",
+			movie:          &Movie{Poster: "main.jpg", Posters: posters},
+			expectedPoster: posters[2],
+			expectedOK:     true,
+		}, {
+			desc:           "poster field only",
+			movie:          &Movie{Poster: "other.jpg", Posters: posters},
+			expectedPoster: Image{URL: "other.jpg"},
+			expectedOK:     true,
+		}, {
+			desc:           "featured poster",
+			movie:          &Movie{Posters: posters},
+			expectedPoster: posters[1],
+			expectedOK:     true,
+		}, {
+			desc:           "first poster",
+			movie:          &Movie{Posters: posters[:1]},
+			expectedPoster: posters[0],
+			expectedOK:     true,
+		}, {
+			desc:  "no poster",
+			movie: &Movie{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			poster, ok := testCase.movie.GetPrimaryPoster()
+			assert.Equal(testCase.expectedPoster, poster)
+			assert.Equal(testCase.expectedOK, ok)
+		})
+	}
+}
+
+func TestMovie_GetTrailer(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &Movie{
+		Videos: []Video{
+			{URL: "song.mp4", Type: "Song", Featured: true},
+			{URL: "teaser.mp4", Type: "Trailer"},
+			{URL: "trailer.mp4", Type: "Trailer", Featured: true},
+		},
+	}
+
+	trailer, ok := m.GetTrailer()
+	assert.True(ok)
+	assert.Equal("trailer.mp4", trailer.URL)
+
+	video, ok := m.GetFeaturedVideo()
+	assert.True(ok)
+	assert.Equal("song.mp4", video.URL)
+
+	m.Trailer = Video{URL: "main-trailer.mp4"}
+	trailer, ok = m.GetTrailer()
+	assert.True(ok)
+	assert.Equal("main-trailer.mp4", trailer.URL)
+
+	groups := GroupVideosByType(m.Videos)
+	assert.Len(groups["trailer"], 2)
+	assert.Len(groups["song"], 1)
+
+	_, ok = new(Person).GetFeaturedStill()
+	assert.False(ok)
+}
+
+func TestImageCDN_URL(t *testing.T) {
+	assert := assert.New(t)
+
+	image := Image{URL: "https://example.com/original.jpg", Key: "abc123"}
+
+	assert.Equal("https://example.com/original.jpg", ImageCDN{}.URL(image, 300, 450))
+	cdn := ImageCDN{URLTemplate: "https://images.example.com/{width}x{height}/{key}"}
+	assert.Equal("https://images.example.com/300x450/abc123", cdn.URL(image, 300, 450))
+	assert.Equal("https://example.com/original.jpg", cdn.URL(Image{URL: "https://example.com/original.jpg"}, 300, 450))
+}
@@ -1,6 +1,7 @@
 package moviebuff
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -65,15 +66,7 @@ type Movie struct {
 	RunningTime int `json:"runningTime"`
 
 	// Details of the trailer of the movie.
-	Trailer struct {
-		Featured  bool   `json:"featured"`
-		URL       string `json:"url"`
-		EmbedURL  string `json:"embedUrl"`
-		Key       string `json:"key"`
-		Caption   string `json:"caption"`
-		Thumbnail string `json:"thumbnail"`
-		Type      string `json:"type"`
-	} `json:"trailer"`
+	Trailer Video `json:"trailer"`
 
 	// An list containing the alternate titles of the movie.
 	AlternateTitles []string `json:"alternateTitles"`
@@ -106,48 +99,18 @@ type Movie struct {
 	Trivia []string `json:"trivia"`
 
 	// An object containing the value and the count of the ratings for the movie.
-	MovieRating struct {
-		Value string `json:"value"`
-		Count int    `json:"count"`
-	} `json:"movieRating"`
+	MovieRating Rating `json:"movieRating"`
 
 	// An object containing the value and the count of the ratings for the movie's tracks.
-	MusicRating struct {
-		Value string `json:"value"`
-		Count int    `json:"count"`
-	} `json:"musicRating"`
+	MusicRating Rating `json:"musicRating"`
 
 	// An list containing the cast of the movie.
-	Cast []struct {
-		Name         string `json:"name"`
-		Poster       string `json:"poster"`
-		Type         string `json:"type"`
-		URL          string `json:"url"`
-		UUID         string `json:"uuid"`
-		Role         string `json:"role"`
-		Department   string `json:"department"`
-		Primary      bool   `json:"primary"`
-		Character    string `json:"character"`
-		MoviebuffURL string `json:"moviebuffUrl"`
-		APIPath      string `json:"apiPath"`
-	} `json:"cast"`
+	Cast []Credit `json:"cast"`
 
 	// An list containing the crew of the movie grouped by department.
 	Crew []struct {
-		Department string `json:"department"`
-		Roles      []struct {
-			Name         string `json:"name"`
-			Poster       string `json:"poster"`
-			Type         string `json:"type"`
-			URL          string `json:"url"`
-			UUID         string `json:"uuid"`
-			Role         string `json:"role"`
-			Department   string `json:"department"`
-			Primary      bool   `json:"primary"`
-			Character    string `json:"character"`
-			MoviebuffURL string `json:"moviebuffUrl"`
-			APIPath      string `json:"apiPath"`
-		} `json:"roles"`
+		Department string   `json:"department"`
+		Roles      []Credit `json:"roles"`
 	} `json:"crew"`
 
 	// An list containing the music labels for the movies tracks.
@@ -162,33 +125,13 @@ type Movie struct {
 	} `json:"musicLabels"`
 
 	// An list containing the posters of the movie.
-	Posters []struct {
-		Featured bool   `json:"featured"`
-		URL      string `json:"url"`
-		Key      string `json:"key"`
-		Caption  string `json:"caption"`
-		Type     string `json:"type"`
-	} `json:"posters"`
+	Posters []Image `json:"posters"`
 
 	// An list containing the videos of the movie.
-	Videos []struct {
-		Featured  bool   `json:"featured"`
-		URL       string `json:"url"`
-		EmbedURL  string `json:"embedUrl"`
-		Key       string `json:"key"`
-		Caption   string `json:"caption"`
-		Thumbnail string `json:"thumbnail"`
-		Type      string `json:"type"`
-	} `json:"videos"`
+	Videos []Video `json:"videos"`
 
 	// An list containing the stills of the movie.
-	Stills []struct {
-		Featured bool   `json:"featured"`
-		URL      string `json:"url"`
-		Key      string `json:"key"`
-		Caption  string `json:"caption"`
-		Type     string `json:"type"`
-	} `json:"stills"`
+	Stills []Image `json:"stills"`
 
 	// An list containing the news articles related to the movie.
 	News []struct {
@@ -202,28 +145,10 @@ type Movie struct {
 	// An list containing the various connections of the movie with other movie.
 	// It is an list of objects with each object having a connectionType that
 	// specifies how the two movies are related to each other.
-	Connections []struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-		// Release Dates mapped against their corresponding country code like "IN" : "2013-12-20".
-		ReleaseDates map[string]string `json:"releaseDates"`
-
-		// Certifications mapped against their corresponding country code like "IN" : "A".
-		Certifications map[string]string `json:"certifications"`
-
-		Language       string `json:"language"`
-		Type           string `json:"type"`
-		UUID           string `json:"uuid"`
-		Poster         string `json:"poster"`
-		MoviebuffURL   string `json:"moviebuffUrl"`
-		APIPath        string `json:"apiPath"`
-		ConnectionType string `json:"connectionType"`
-	} `json:"connections"`
+	Connections []Connection `json:"connections"`
 
 	// An object where each release status is mapped against its corresponding country code.
-	ReleaseStatuses struct {
-		AE string `json:"AE"`
-	} `json:"releaseStatuses"`
+	ReleaseStatuses ReleaseStatuses `json:"releaseStatuses"`
 
 	ThirdPartyIdentifiers []ThirdPartyIdentifier `json:"thirdPartyIdentifiers"`
 
@@ -231,6 +156,41 @@ type Movie struct {
 	APIPath      string `json:"apiPath"`
 }
 
+// Connection is a movie related to another movie. ConnectionType specifies how the two movies are related,
+// like a sequel, a remake or a dubbed version.
+type Connection struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// Release Dates mapped against their corresponding country code like "IN" : "2013-12-20".
+	ReleaseDates map[string]string `json:"releaseDates"`
+
+	// Certifications mapped against their corresponding country code like "IN" : "A".
+	Certifications map[string]string `json:"certifications"`
+
+	Language       string `json:"language"`
+	Type           string `json:"type"`
+	UUID           string `json:"uuid"`
+	Poster         string `json:"poster"`
+	MoviebuffURL   string `json:"moviebuffUrl"`
+	APIPath        string `json:"apiPath"`
+	ConnectionType string `json:"connectionType"`
+}
+
+// Credit contains the details of a person or an entity credited in the cast or crew of a movie.
+type Credit struct {
+	Name         string `json:"name"`
+	Poster       string `json:"poster"`
+	Type         string `json:"type"`
+	URL          string `json:"url"`
+	UUID         string `json:"uuid"`
+	Role         string `json:"role"`
+	Department   string `json:"department"`
+	Primary      bool   `json:"primary"`
+	Character    string `json:"character"`
+	MoviebuffURL string `json:"moviebuffUrl"`
+	APIPath      string `json:"apiPath"`
+}
+
 // ThirdPartyIdentifier has third-party sources
 type ThirdPartyIdentifier struct {
 	IDs    []string `json:"ids"`
@@ -251,6 +211,23 @@ func (m *Movie) GetEarliestReleaseYear() (releaseYear int) {
 	return
 }
 
+// GetEarliestReleaseDate returns the date at which movie was first released anywhere in the world.
+// ok is false if release date is not available
+func (m *Movie) GetEarliestReleaseDate() (releaseDate time.Time, ok bool) {
+	return earliestReleaseDate(m.ReleaseDates)
+}
+
+// earliestReleaseDate returns the earliest of the release dates mapped against their country code.
+// Dates which cannot be parsed are ignored.
+func earliestReleaseDate(releaseDates map[string]string) (releaseDate time.Time, ok bool) {
+	for _, v := range releaseDates {
+		if t, err := time.Parse("2006-01-02", v); err == nil && (t.Before(releaseDate) || !ok) {
+			releaseDate, ok = t, true
+		}
+	}
+	return
+}
+
 // GetThirdPartyIDsBySource returns third-party IDs for provided source.
 // Returns nil if source ID is not available
 func (m *Movie) GetThirdPartyIDsBySource(sourceID string) []string {
@@ -261,3 +238,59 @@ func (m *Movie) GetThirdPartyIDsBySource(sourceID string) []string {
 	}
 	return nil
 }
+
+// GetReleaseStatus returns the release status of the movie in the given country code like "IN".
+// Returns empty value if the status is not available for the country
+func (m *Movie) GetReleaseStatus(country string) ReleaseStatus {
+	return m.ReleaseStatuses.Get(country)
+}
+
+// GetRunningTime returns the running time of the movie as a time.Duration.
+// Returns 0 if running time is not available
+func (m *Movie) GetRunningTime() time.Duration {
+	if m.RunningTime <= 0 {
+		return 0
+	}
+	return time.Duration(m.RunningTime) * time.Second
+}
+
+// GetFormattedRunningTime returns the running time of the movie in a human readable form like "2h 14m".
+// Returns empty string if running time is not available
+func (m *Movie) GetFormattedRunningTime() string {
+	return FormatRunningTime(m.GetRunningTime())
+}
+
+// FormatRunningTime formats d rounded to the nearest minute like "2h 14m", "2h" or "45m".
+// Returns empty string if d is not positive.
+func FormatRunningTime(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	if minutes == 0 {
+		minutes = 1
+	}
+
+	hours, minutes := minutes/60, minutes%60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+}
+
+// GetThirdPartyIDsBySourceName returns third-party IDs for the source with the given name like "IMDb".
+// Source names are compared ignoring case and punctuation.
+// Returns nil if source is not available
+func (m *Movie) GetThirdPartyIDsBySourceName(sourceName string) []string {
+	for _, id := range m.ThirdPartyIdentifiers {
+		if normalizeName(id.Source.Name) == normalizeName(sourceName) {
+			return id.IDs
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package moviebuff
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovie_GetRunningTime(t *testing.T) {
+	var testCases = []struct {
+		desc              string
+		runningTime       int
+		expectedDuration  time.Duration
+		expectedFormatted string
+	}{
+		{
+			desc:              "running time not available",
+			runningTime:       0,
+			expectedDuration:  0,
+			expectedFormatted: "",
+		}, {
+			desc:              "negative running time",
+			runningTime:       -60,
+			expectedDuration:  0,
+			expectedFormatted: "",
+		}, {
+			desc:              "hours and minutes",
+			runningTime:       8040,
+			expectedDuration:  2*time.Hour + 14*time.Minute,
+			expectedFormatted: "2h 14m",
+		}, {
+			desc:              "exact hours",
+			runningTime:       7200,
+			expectedDuration:  2 * time.Hour,
+			expectedFormatted: "2h",
+		}, {
+			desc:              "minutes only",
+			runningTime:       2700,
+			expectedDuration:  45 * time.Minute,
+			expectedFormatted: "45m",
+		}, {
+			desc:              "seconds are rounded to the nearest minute",
+			runningTime:       8070,
+			expectedDuration:  2*time.Hour + 14*time.Minute + 30*time.Second,
+			expectedFormatted: "2h 15m",
+		}, {
+			desc:              "less than a minute",
+			runningTime:       20,
+			expectedDuration:  20 * time.Second,
+			expectedFormatted: "1m",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			m := &Movie{RunningTime: testCase.runningTime}
+			assert.Equal(testCase.expectedDuration, m.GetRunningTime())
+			assert.Equal(testCase.expectedFormatted, m.GetFormattedRunningTime())
+		})
+	}
+}
+
+func TestMovie_ReleaseStatuses(t *testing.T) {
+	assert := assert.New(t)
+
+	m := new(Movie)
+	err := json.Unmarshal([]byte(`{
+		"releaseStatuses": {
+			"AE": "Released",
+			"IN": "released",
+			"US": "upcoming",
+			"GB": "announced",
+			"SG": "on hold"
+		}
+	}`), m)
+	assert.NoError(err)
+
+	assert.Equal("Released", m.ReleaseStatuses.AE)
+	assert.Equal(RELEASE_STATUS_RELEASED, m.GetReleaseStatus("AE"))
+	assert.Equal(RELEASE_STATUS_UPCOMING, m.GetReleaseStatus("US"))
+	assert.Equal(ReleaseStatus("on hold"), m.GetReleaseStatus("SG"))
+	assert.Equal(ReleaseStatus(""), m.GetReleaseStatus("FR"))
+
+	assert.Equal([]string{"AE", "GB", "IN", "SG", "US"}, m.ReleaseStatuses.Countries())
+	assert.Equal([]string{"AE", "IN"}, m.ReleaseStatuses.CountriesWithStatus(RELEASE_STATUS_RELEASED))
+	assert.Nil(m.ReleaseStatuses.CountriesWithStatus(RELEASE_STATUS_CANCELLED))
+	assert.Equal(map[ReleaseStatus][]string{
+		RELEASE_STATUS_RELEASED:  {"AE", "IN"},
+		RELEASE_STATUS_UPCOMING:  {"US"},
+		RELEASE_STATUS_ANNOUNCED: {"GB"},
+		"on hold":                {"SG"},
+	}, m.ReleaseStatuses.ByStatus())
+
+	content, err := json.Marshal(m.ReleaseStatuses)
+	assert.NoError(err)
+	assert.JSONEq(`{"AE":"Released","IN":"released","US":"upcoming","GB":"announced","SG":"on hold"}`, string(content))
+
+	m.ReleaseStatuses.Statuses["US"] = RELEASE_STATUS_RELEASED
+	m.ReleaseStatuses.AE = "cancelled"
+	assert.Equal(RELEASE_STATUS_CANCELLED, m.ReleaseStatuses.Get("AE"))
+	assert.Equal([]string{"AE"}, m.ReleaseStatuses.CountriesWithStatus(RELEASE_STATUS_CANCELLED))
+	content, err = json.Marshal(m.ReleaseStatuses)
+	assert.NoError(err)
+	assert.JSONEq(`{"AE":"cancelled","IN":"released","US":"released","GB":"announced","SG":"on hold"}`, string(content))
+
+	// Statuses is used when the deprecated field is left unchanged.
+	m.ReleaseStatuses.AE = "Released"
+	m.ReleaseStatuses.Statuses["AE"] = RELEASE_STATUS_UPCOMING
+	assert.Equal(RELEASE_STATUS_UPCOMING, m.ReleaseStatuses.Get("AE"))
+	content, err = json.Marshal(m.ReleaseStatuses)
+	assert.NoError(err)
+	assert.JSONEq(`{"AE":"upcoming","IN":"released","US":"released","GB":"announced","SG":"on hold"}`, string(content))
+
+	legacy := ReleaseStatuses{AE: "upcoming"}
+	assert.Equal(RELEASE_STATUS_UPCOMING, legacy.Get("AE"))
+	assert.Equal([]string{"AE"}, legacy.Countries())
+
+	content, err = json.Marshal(legacy)
+	assert.NoError(err)
+	assert.JSONEq(`{"AE":"upcoming"}`, string(content))
+}
@@ -31,6 +31,7 @@ var (
 	ErrInvalidToken         = errors.New("access denied")
 	ErrResponseNotReceived  = errors.New("could not receive valid response")
 	ErrResourceDoesNotExist = errors.New("resource does not exist")
+	ErrInvalidContentTitle  = errors.New("invalid content title")
 )
 
 // Moviebuff allows to access to information in moviebuff using resource ids.
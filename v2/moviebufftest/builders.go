@@ -0,0 +1,331 @@
+package moviebufftest
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+)
+
+// slug returns the URL identifier of a name, like "12-years-a-slave" for "12 Years a Slave".
+func slug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// MovieBuilder builds Movie fixtures fluently:
+//
+//	m := moviebufftest.NewMovie("uuid", "12 Years a Slave").
+//		Language("English").
+//		ReleaseDate("IN", "2014-02-28").
+//		Director("Steve McQueen", "steve-uuid").
+//		Build()
+type MovieBuilder struct {
+	m moviebuff.Movie
+}
+
+// NewMovie starts building a movie with the given UUID and name. Its URL is derived from its name.
+func NewMovie(uuid, name string) *MovieBuilder {
+	return &MovieBuilder{m: moviebuff.Movie{
+		UUID: uuid,
+		Name: name,
+		URL:  slug(name),
+		Type: typeMovie,
+	}}
+}
+
+// URL sets the URL identifier of the movie.
+func (b *MovieBuilder) URL(url string) *MovieBuilder {
+	b.m.URL = url
+	return b
+}
+
+// Language sets the primary language of the movie.
+func (b *MovieBuilder) Language(language string) *MovieBuilder {
+	b.m.Language = language
+	return b
+}
+
+// Genres adds genres to the movie.
+func (b *MovieBuilder) Genres(genres ...string) *MovieBuilder {
+	b.m.Genres = append(b.m.Genres, genres...)
+	return b
+}
+
+// RunningTime sets the running time of the movie, rounded down to the second.
+func (b *MovieBuilder) RunningTime(d time.Duration) *MovieBuilder {
+	b.m.RunningTime = int(d / time.Second)
+	return b
+}
+
+// ReleaseDate sets the release date of the movie in a country, like ReleaseDate("IN", "2013-12-20").
+func (b *MovieBuilder) ReleaseDate(country, date string) *MovieBuilder {
+	if b.m.ReleaseDates == nil {
+		b.m.ReleaseDates = map[string]string{}
+	}
+	b.m.ReleaseDates[country] = date
+	return b
+}
+
+// ReleaseStatus sets the release status of the movie in a country.
+func (b *MovieBuilder) ReleaseStatus(country string, status moviebuff.ReleaseStatus) *MovieBuilder {
+	if b.m.ReleaseStatuses.Statuses == nil {
+		b.m.ReleaseStatuses.Statuses = map[string]moviebuff.ReleaseStatus{}
+	}
+	b.m.ReleaseStatuses.Statuses[country] = status
+	if country == "AE" {
+		b.m.ReleaseStatuses.AE = string(status)
+	}
+	return b
+}
+
+// Certification sets the certification of the movie in a country, like Certification("IN", "UA").
+func (b *MovieBuilder) Certification(country, code string) *MovieBuilder {
+	if b.m.Certifications == nil {
+		b.m.Certifications = map[string]string{}
+	}
+	b.m.Certifications[country] = code
+	return b
+}
+
+// Rating sets the movie rating.
+func (b *MovieBuilder) Rating(value string, count int) *MovieBuilder {
+	b.m.MovieRating = moviebuff.Rating{Value: value, Count: count}
+	return b
+}
+
+// Poster sets the poster of the movie.
+func (b *MovieBuilder) Poster(url string) *MovieBuilder {
+	b.m.Poster = url
+	return b
+}
+
+// Cast adds a person to the cast of the movie.
+func (b *MovieBuilder) Cast(name, uuid, character string) *MovieBuilder {
+	b.m.Cast = append(b.m.Cast, moviebuff.Credit{
+		Name:       name,
+		UUID:       uuid,
+		URL:        slug(name),
+		Type:       typePerson,
+		Role:       "Actor",
+		Department: moviebuff.DEPARTMENT_CAST,
+		Character:  character,
+	})
+	return b
+}
+
+// Crew adds a credit to the crew of the movie, grouped with the other credits of its department.
+func (b *MovieBuilder) Crew(department string, credit moviebuff.Credit) *MovieBuilder {
+	if credit.Department == "" {
+		credit.Department = department
+	}
+	for i := range b.m.Crew {
+		if b.m.Crew[i].Department == department {
+			b.m.Crew[i].Roles = append(b.m.Crew[i].Roles, credit)
+			return b
+		}
+	}
+	b.m.Crew = append(b.m.Crew, struct {
+		Department string             `json:"department"`
+		Roles      []moviebuff.Credit `json:"roles"`
+	}{Department: department, Roles: []moviebuff.Credit{credit}})
+	return b
+}
+
+// Director adds a person as director of the movie.
+func (b *MovieBuilder) Director(name, uuid string) *MovieBuilder {
+	return b.Crew("Direction", moviebuff.Credit{Name: name, UUID: uuid, URL: slug(name), Type: typePerson, Role: "Director", Primary: true})
+}
+
+// Connection adds a connection of the given type, like "Sequel", to another movie.
+func (b *MovieBuilder) Connection(connectionType string, m *moviebuff.Movie) *MovieBuilder {
+	b.m.Connections = append(b.m.Connections, moviebuff.Connection{
+		Name:           m.Name,
+		URL:            m.URL,
+		UUID:           m.UUID,
+		Type:           m.Type,
+		Language:       m.Language,
+		ReleaseDates:   m.ReleaseDates,
+		Certifications: m.Certifications,
+		ConnectionType: connectionType,
+	})
+	return b
+}
+
+// With calls fn with the movie being built, to set fields without a builder method.
+func (b *MovieBuilder) With(fn func(m *moviebuff.Movie)) *MovieBuilder {
+	fn(&b.m)
+	return b
+}
+
+// Build returns a copy of the movie, which is not changed by later calls to the builder.
+func (b *MovieBuilder) Build() *moviebuff.Movie {
+	return deepCopy(&b.m).(*moviebuff.Movie)
+}
+
+// PersonBuilder builds Person fixtures fluently.
+type PersonBuilder struct {
+	p       moviebuff.Person
+	credits []credit
+}
+
+// credit is a role played in a movie by a person or an entity.
+type credit struct {
+	department string
+	role       moviebuff.FilmographyEntry
+}
+
+// NewPerson starts building a person with the given UUID and name. Its URL is derived from its name.
+func NewPerson(uuid, name string) *PersonBuilder {
+	return &PersonBuilder{p: moviebuff.Person{
+		UUID: uuid,
+		Name: name,
+		URL:  slug(name),
+		Type: typePerson,
+	}}
+}
+
+// URL sets the URL identifier of the person.
+func (b *PersonBuilder) URL(url string) *PersonBuilder {
+	b.p.URL = url
+	return b
+}
+
+// Birthday sets the birthday of the person, like "1969-10-09".
+func (b *PersonBuilder) Birthday(date string) *PersonBuilder {
+	b.p.Birthday = date
+	return b
+}
+
+// Biography sets the biography of the person.
+func (b *PersonBuilder) Biography(biography string) *PersonBuilder {
+	b.p.Biography = biography
+	return b
+}
+
+// Credit adds a role played by the person in a movie, like Credit("Cast", "Actor", m).
+func (b *PersonBuilder) Credit(department, role string, m *moviebuff.Movie) *PersonBuilder {
+	b.credits = append(b.credits, newCredit(department, role, m))
+	return b
+}
+
+// With calls fn with the person being built, to set fields without a builder method.
+func (b *PersonBuilder) With(fn func(p *moviebuff.Person)) *PersonBuilder {
+	fn(&b.p)
+	return b
+}
+
+// Build returns a copy of the person, which is not changed by later calls to the builder.
+func (b *PersonBuilder) Build() *moviebuff.Person {
+	p := deepCopy(&b.p).(*moviebuff.Person)
+	setCredits(p, b.credits)
+	return p
+}
+
+// EntityBuilder builds Entity fixtures fluently.
+type EntityBuilder struct {
+	e       moviebuff.Entity
+	credits []credit
+}
+
+// NewEntity starts building an entity with the given UUID and name. Its URL is derived from its name.
+func NewEntity(uuid, name string) *EntityBuilder {
+	return &EntityBuilder{e: moviebuff.Entity{
+		UUID: uuid,
+		Name: name,
+		URL:  slug(name),
+		Type: typeEntity,
+	}}
+}
+
+// URL sets the URL identifier of the entity.
+func (b *EntityBuilder) URL(url string) *EntityBuilder {
+	b.e.URL = url
+	return b
+}
+
+// Services adds services offered by the entity.
+func (b *EntityBuilder) Services(services ...string) *EntityBuilder {
+	b.e.Services = append(b.e.Services, services...)
+	return b
+}
+
+// Credit adds a role played by the entity in a movie, like Credit("Production", "Producer", m).
+func (b *EntityBuilder) Credit(department, role string, m *moviebuff.Movie) *EntityBuilder {
+	b.credits = append(b.credits, newCredit(department, role, m))
+	return b
+}
+
+// With calls fn with the entity being built, to set fields without a builder method.
+func (b *EntityBuilder) With(fn func(e *moviebuff.Entity)) *EntityBuilder {
+	fn(&b.e)
+	return b
+}
+
+// Build returns a copy of the entity, which is not changed by later calls to the builder.
+func (b *EntityBuilder) Build() *moviebuff.Entity {
+	e := deepCopy(&b.e).(*moviebuff.Entity)
+	setCredits(e, b.credits)
+	return e
+}
+
+func newCredit(department, role string, m *moviebuff.Movie) credit {
+	return credit{department: department, role: moviebuff.FilmographyEntry{
+		Name:           m.Name,
+		URL:            m.URL,
+		UUID:           m.UUID,
+		Type:           m.Type,
+		Language:       m.Language,
+		Poster:         m.Poster,
+		ReleaseDates:   m.ReleaseDates,
+		Certifications: m.Certifications,
+		Role:           role,
+		Department:     department,
+		MoviebuffURL:   m.MoviebuffURL,
+		APIPath:        m.APIPath,
+	}}
+}
+
+// setCredits sets the credits of a person or an entity, grouped by department.
+// Credits are anonymous structs, so they are set through their JSON representation:
+// the fields of FilmographyEntry match the JSON keys of credit roles case insensitively.
+func setCredits(v interface{}, credits []credit) {
+	if len(credits) == 0 {
+		return
+	}
+
+	type department struct {
+		Department string                       `json:"department"`
+		Roles      []moviebuff.FilmographyEntry `json:"roles"`
+	}
+	var departments []department
+	index := map[string]int{}
+	for _, c := range credits {
+		i, ok := index[c.department]
+		if !ok {
+			i = len(departments)
+			index[c.department] = i
+			departments = append(departments, department{Department: c.department})
+		}
+		departments[i].Roles = append(departments[i].Roles, c.role)
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"credits": departments})
+	if err == nil {
+		err = json.Unmarshal(data, v)
+	}
+	if err != nil {
+		panic("moviebufftest: setting credits: " + err.Error())
+	}
+}
@@ -0,0 +1,389 @@
+package moviebufftest
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+)
+
+// Methods of the Moviebuff interface, used to script errors and inspect calls
+const (
+	METHOD_GET_MOVIE            = "GetMovie"
+	METHOD_GET_PERSON           = "GetPerson"
+	METHOD_GET_ENTITY           = "GetEntity"
+	METHOD_GET_RESOURCES        = "GetResources"
+	METHOD_GET_CERTIFICATIONS   = "GetCertifications"
+	METHOD_GET_HOLIDAY_CALENDAR = "GetHolidayCalendar"
+	METHOD_GET_LANGUAGES        = "GetLanguages"
+	METHOD_GET_MAPPED_CPL       = "GetMappedCPL"
+)
+
+// Call is a call made to a Fake.
+type Call struct {
+	Method string
+
+	// Args are the arguments of the call after the context, formatted as strings.
+	Args []string
+}
+
+// Fake is an in-memory implementation of moviebuff.Moviebuff backed by maps. It is safe for concurrent use.
+//
+// Fixtures are copied when they are added and when they are returned, looked up by UUID or URL like the API does,
+// and moviebuff.ErrResourceDoesNotExist is returned for unknown IDs.
+type Fake struct {
+	mu             sync.Mutex
+	movies         map[string]*moviebuff.Movie
+	people         map[string]*moviebuff.Person
+	entities       map[string]*moviebuff.Entity
+	resources      map[moviebuff.ResourceType][]moviebuff.Resource
+	certifications []moviebuff.Certification
+	calendars      map[string]*moviebuff.Calendar
+	languages      []moviebuff.Language
+	mappedCPLs     map[string]*moviebuff.MappedCPL
+	scripted       map[string][]error
+	failures       map[string]map[string]error
+	calls          []Call
+}
+
+var _ moviebuff.Moviebuff = (*Fake)(nil)
+
+// NewFake returns an empty Fake.
+func NewFake() *Fake {
+	return &Fake{
+		movies:     map[string]*moviebuff.Movie{},
+		people:     map[string]*moviebuff.Person{},
+		entities:   map[string]*moviebuff.Entity{},
+		resources:  map[moviebuff.ResourceType][]moviebuff.Resource{},
+		calendars:  map[string]*moviebuff.Calendar{},
+		mappedCPLs: map[string]*moviebuff.MappedCPL{},
+		scripted:   map[string][]error{},
+		failures:   map[string]map[string]error{},
+	}
+}
+
+// AddMovie adds movies, returned by their UUID, URL or alternate URLs.
+func (f *Fake) AddMovie(movies ...*moviebuff.Movie) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, m := range movies {
+		m = deepCopy(m).(*moviebuff.Movie)
+		for _, id := range append([]string{m.UUID, m.URL}, m.AlternateUrls...) {
+			if id != "" {
+				f.movies[id] = m
+			}
+		}
+		f.resources[moviebuff.RESOURCE_TYPE_MOVIES] = append(f.resources[moviebuff.RESOURCE_TYPE_MOVIES], moviebuff.Resource{
+			Name: m.Name, URL: m.URL, UUID: m.UUID, Type: m.Type, Poster: m.Poster, APIPath: m.APIPath, MoviebuffURL: m.MoviebuffURL,
+		})
+	}
+	return f
+}
+
+// AddPerson adds people, returned by their UUID, URL or alternate URLs.
+func (f *Fake) AddPerson(people ...*moviebuff.Person) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, p := range people {
+		p = deepCopy(p).(*moviebuff.Person)
+		for _, id := range append([]string{p.UUID, p.URL}, p.AlternateUrls...) {
+			if id != "" {
+				f.people[id] = p
+			}
+		}
+		f.resources[moviebuff.RESOURCE_TYPE_PEOPLE] = append(f.resources[moviebuff.RESOURCE_TYPE_PEOPLE], moviebuff.Resource{
+			Name: p.Name, URL: p.URL, UUID: p.UUID, Type: p.Type, Poster: p.Poster, APIPath: p.APIPath, MoviebuffURL: p.MoviebuffURL,
+		})
+	}
+	return f
+}
+
+// AddEntity adds entities, returned by their UUID, URL or alternate URLs.
+func (f *Fake) AddEntity(entities ...*moviebuff.Entity) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, e := range entities {
+		e = deepCopy(e).(*moviebuff.Entity)
+		for _, id := range append([]string{e.UUID, e.URL}, e.AlternateUrls...) {
+			if id != "" {
+				f.entities[id] = e
+			}
+		}
+		f.resources[moviebuff.RESOURCE_TYPE_ENTITIES] = append(f.resources[moviebuff.RESOURCE_TYPE_ENTITIES], moviebuff.Resource{
+			Name: e.Name, URL: e.URL, UUID: e.UUID, Type: e.Type, Poster: e.Poster, APIPath: e.APIPath, MoviebuffURL: e.MoviebuffURL,
+		})
+	}
+	return f
+}
+
+// AddCertifications adds certifications, filtered by the code or UUID of their country when requested for a country.
+func (f *Fake) AddCertifications(certifications ...moviebuff.Certification) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.certifications = append(f.certifications, deepCopy(certifications).([]moviebuff.Certification)...)
+	return f
+}
+
+// AddHolidayCalendar adds the holiday calendar of the country with the given ID.
+func (f *Fake) AddHolidayCalendar(countryID string, c *moviebuff.Calendar) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calendars[countryID] = deepCopy(c).(*moviebuff.Calendar)
+	return f
+}
+
+// AddLanguages adds languages.
+func (f *Fake) AddLanguages(languages ...moviebuff.Language) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.languages = append(f.languages, deepCopy(languages).([]moviebuff.Language)...)
+	return f
+}
+
+// AddMappedCPL adds a mapped CPL, returned by its UUID or its ID.
+func (f *Fake) AddMappedCPL(c *moviebuff.MappedCPL) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c = deepCopy(c).(*moviebuff.MappedCPL)
+	f.mappedCPLs[c.UUID] = c
+	f.mappedCPLs[strconv.Itoa(c.ID)] = c
+	return f
+}
+
+// Script sets the errors returned by the next calls to method, in order.
+// A nil error lets the call through, so Script(METHOD_GET_MOVIE, nil, err) fails the second call only.
+// Scripted errors take precedence over the failures set with FailOn.
+func (f *Fake) Script(method string, errs ...error) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.scripted[method] = append(f.scripted[method], errs...)
+	return f
+}
+
+// FailOn makes every call to method with the given ID return err, until cleared with a nil err.
+// The ID is the first argument after the context, like the movie ID of GetMovie or the country of GetCertifications.
+func (f *Fake) FailOn(method, id string, err error) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err == nil {
+		delete(f.failures[method], id)
+		return f
+	}
+	if f.failures[method] == nil {
+		f.failures[method] = map[string]error{}
+	}
+	f.failures[method][id] = err
+	return f
+}
+
+// Calls returns every call made, in order.
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]Call(nil), f.calls...)
+}
+
+// CallsTo returns the calls made to method, in order.
+func (f *Fake) CallsTo(method string) []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var calls []Call
+	for _, c := range f.calls {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// IDs returns the first argument of the calls made to method, in order, like the IDs of the movies fetched with GetMovie.
+func (f *Fake) IDs(method string) []string {
+	var ids []string
+	for _, c := range f.CallsTo(method) {
+		if len(c.Args) > 0 {
+			ids = append(ids, c.Args[0])
+		}
+	}
+	return ids
+}
+
+// ResetCalls forgets the calls made.
+func (f *Fake) ResetCalls() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = nil
+}
+
+// call records a call and returns the error scripted for it, if any. f.mu must be held.
+func (f *Fake) call(ctx context.Context, method string, args ...string) error {
+	f.calls = append(f.calls, Call{Method: method, Args: args})
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if errs := f.scripted[method]; len(errs) > 0 {
+		f.scripted[method] = errs[1:]
+		if errs[0] != nil {
+			return errs[0]
+		}
+	}
+	if len(args) > 0 {
+		if err, ok := f.failures[method][args[0]]; ok {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Fake) GetMovie(ctx context.Context, id string) (*moviebuff.Movie, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call(ctx, METHOD_GET_MOVIE, id); err != nil {
+		return nil, err
+	}
+	m, ok := f.movies[id]
+	if !ok {
+		return nil, moviebuff.ErrResourceDoesNotExist
+	}
+	return deepCopy(m).(*moviebuff.Movie), nil
+}
+
+func (f *Fake) GetPerson(ctx context.Context, id string) (*moviebuff.Person, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call(ctx, METHOD_GET_PERSON, id); err != nil {
+		return nil, err
+	}
+	p, ok := f.people[id]
+	if !ok {
+		return nil, moviebuff.ErrResourceDoesNotExist
+	}
+	return deepCopy(p).(*moviebuff.Person), nil
+}
+
+func (f *Fake) GetEntity(ctx context.Context, id string) (*moviebuff.Entity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call(ctx, METHOD_GET_ENTITY, id); err != nil {
+		return nil, err
+	}
+	e, ok := f.entities[id]
+	if !ok {
+		return nil, moviebuff.ErrResourceDoesNotExist
+	}
+	return deepCopy(e).(*moviebuff.Entity), nil
+}
+
+// GetResources pages through the resources in the order they were added, like Server does.
+func (f *Fake) GetResources(ctx context.Context, resourceType moviebuff.ResourceType, limit, page int) (*moviebuff.Resources, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call(ctx, METHOD_GET_RESOURCES, string(resourceType), strconv.Itoa(limit), strconv.Itoa(page)); err != nil {
+		return nil, err
+	}
+	switch resourceType {
+	case moviebuff.RESOURCE_TYPE_MOVIES, moviebuff.RESOURCE_TYPE_PEOPLE, moviebuff.RESOURCE_TYPE_ENTITIES:
+	default:
+		return nil, moviebuff.ErrResourceDoesNotExist
+	}
+
+	if limit <= 0 {
+		limit = DEFAULT_LIMIT
+	}
+	if limit > MAX_LIMIT {
+		limit = MAX_LIMIT
+	}
+	if page <= 0 {
+		page = 1
+	}
+
+	all := f.resources[resourceType]
+	resources := &moviebuff.Resources{Data: []moviebuff.Resource{}}
+	for i := (page - 1) * limit; i < page*limit && i < len(all); i++ {
+		resources.Data = append(resources.Data, all[i])
+	}
+
+	pageURL := func(page int) string {
+		return fmt.Sprintf("/resources/%s?limit=%d&page=%d", resourceType, limit, page)
+	}
+	if page > 1 {
+		resources.Prev = pageURL(page - 1)
+	}
+	if page*limit < len(all) {
+		resources.Next = pageURL(page + 1)
+	}
+	return resources, nil
+}
+
+func (f *Fake) GetCertifications(ctx context.Context, country string) ([]moviebuff.Certification, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call(ctx, METHOD_GET_CERTIFICATIONS, country); err != nil {
+		return nil, err
+	}
+	var certifications []moviebuff.Certification
+	for _, c := range f.certifications {
+		if country == "" || strings.EqualFold(c.Country.Code, country) || c.Country.UUID == country {
+			certifications = append(certifications, deepCopy(c).(moviebuff.Certification))
+		}
+	}
+	return certifications, nil
+}
+
+func (f *Fake) GetHolidayCalendar(ctx context.Context, countryID string) (*moviebuff.Calendar, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call(ctx, METHOD_GET_HOLIDAY_CALENDAR, countryID); err != nil {
+		return nil, err
+	}
+	c, ok := f.calendars[countryID]
+	if !ok {
+		return nil, moviebuff.ErrResourceDoesNotExist
+	}
+	return deepCopy(c).(*moviebuff.Calendar), nil
+}
+
+func (f *Fake) GetLanguages(ctx context.Context) ([]moviebuff.Language, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call(ctx, METHOD_GET_LANGUAGES); err != nil {
+		return nil, err
+	}
+	return deepCopy(f.languages).([]moviebuff.Language), nil
+}
+
+func (f *Fake) GetMappedCPL(ctx context.Context, cplID string) (*moviebuff.MappedCPL, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call(ctx, METHOD_GET_MAPPED_CPL, cplID); err != nil {
+		return nil, err
+	}
+	c, ok := f.mappedCPLs[cplID]
+	if !ok {
+		return nil, moviebuff.ErrResourceDoesNotExist
+	}
+	return deepCopy(c).(*moviebuff.MappedCPL), nil
+}
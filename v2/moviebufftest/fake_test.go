@@ -0,0 +1,200 @@
+package moviebufftest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilders(t *testing.T) {
+	assert := assert.New(t)
+
+	prequel := NewMovie("m0", "Baahubali: The Beginning").Language("Telugu").Build()
+	m := NewMovie("m1", "Baahubali 2: The Conclusion").
+		Language("Telugu").
+		Genres("Action", "Drama").
+		RunningTime(2*time.Hour+47*time.Minute).
+		ReleaseDate("IN", "2017-04-28").
+		ReleaseStatus("IN", moviebuff.RELEASE_STATUS_RELEASED).
+		Certification("IN", "UA").
+		Rating("4.5", 120).
+		Cast("Prabhas", "p1", "Amarendra Baahubali").
+		Director("S. S. Rajamouli", "p2").
+		Crew("Music", moviebuff.Credit{Name: "M. M. Keeravani", Role: "Music Director"}).
+		Connection("Sequel", prequel).
+		With(func(m *moviebuff.Movie) { m.Synopsis = "The conclusion." }).
+		Build()
+
+	assert.Equal("baahubali-2-the-conclusion", m.URL)
+	assert.Equal("movie", m.Type)
+	assert.Equal(167*time.Minute, m.GetRunningTime())
+	assert.Equal(moviebuff.RELEASE_STATUS_RELEASED, m.GetReleaseStatus("IN"))
+	assert.Equal([]string{"S. S. Rajamouli"}, names(m.GetDirectors()))
+	assert.Equal([]string{"Prabhas"}, names(m.Cast))
+	assert.Equal("Music", m.Crew[1].Department)
+	assert.Equal("Baahubali: The Beginning", m.Connections[0].Name)
+	assert.Equal("The conclusion.", m.Synopsis)
+
+	p := NewPerson("p1", "Prabhas").Birthday("1979-10-23").Credit(moviebuff.DEPARTMENT_CAST, "Actor", m).
+		Credit(moviebuff.DEPARTMENT_CAST, "Actor", prequel).Build()
+	assert.Equal("person", p.Type)
+	assert.Len(p.Credits, 1)
+	assert.Len(p.Credits[0].Roles, 2)
+	assert.Equal("Baahubali 2: The Conclusion", p.GetFilmography()[0].Name)
+	assert.Equal(map[string]string{"IN": "2017-04-28"}, p.Credits[0].Roles[0].ReleaseDates)
+
+	e := NewEntity("e1", "Arka Media Works").Services("Production").Credit("Production", "Producer", m).Build()
+	assert.Equal("arka-media-works", e.URL)
+	assert.Equal("Producer", e.Credits[0].Roles[0].Role)
+}
+
+func names(credits []moviebuff.Credit) []string {
+	var n []string
+	for _, c := range credits {
+		n = append(n, c.Name)
+	}
+	return n
+}
+
+func TestBuilders_Copies(t *testing.T) {
+	assert := assert.New(t)
+
+	b := NewMovie("m1", "Movie One").Genres("Drama").ReleaseDate("IN", "2024-01-12").Cast("Actor One", "p1", "Hero")
+	built := b.Build()
+	b.Genres("Action").ReleaseDate("IN", "2024-02-02").Cast("Actor Two", "p2", "Villain")
+	built.Cast[0].Name = "Changed"
+
+	assert.Equal([]string{"Drama"}, built.Genres)
+	assert.Equal(map[string]string{"IN": "2024-01-12"}, built.ReleaseDates)
+	assert.Len(built.Cast, 1)
+	assert.Equal([]string{"Actor One", "Actor Two"}, names(b.Build().Cast))
+
+	pb := NewPerson("p1", "Person One").With(func(p *moviebuff.Person) { p.Tags = []string{"actor"} })
+	p := pb.Build()
+	p.Tags[0] = "changed"
+	assert.Equal([]string{"actor"}, pb.Build().Tags)
+
+	eb := NewEntity("e1", "Entity One").Services("Production")
+	e := eb.Build()
+	e.Services[0] = "changed"
+	assert.Equal([]string{"Production"}, eb.Build().Services)
+}
+
+func TestFake_Copies(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	fixture := NewMovie("m1", "Movie One").ReleaseDate("IN", "2024-01-12").Cast("Actor One", "p1", "Hero").Build()
+	f := NewFake().AddMovie(fixture).AddHolidayCalendar("IN", &moviebuff.Calendar{Holidays: []moviebuff.Holiday{{Name: "Holi"}}})
+
+	fixture.Cast[0].Name = "Changed by the test"
+	m, err := f.GetMovie(ctx, "m1")
+	assert.NoError(err)
+	m.Cast[0].Name = "Changed by the caller"
+	m.ReleaseDates["IN"] = "2024-02-02"
+
+	m, err = f.GetMovie(ctx, "m1")
+	assert.NoError(err)
+	assert.Equal("Actor One", m.Cast[0].Name)
+	assert.Equal("2024-01-12", m.ReleaseDates["IN"])
+
+	c, err := f.GetHolidayCalendar(ctx, "IN")
+	assert.NoError(err)
+	c.Holidays[0].Name = "Changed"
+	c, err = f.GetHolidayCalendar(ctx, "IN")
+	assert.NoError(err)
+	assert.Equal("Holi", c.Holidays[0].Name)
+
+	spokenIn := []string{"IN"}
+	f.AddLanguages(moviebuff.Language{Name: "Hindi", SpokenIn: spokenIn})
+	spokenIn[0] = "Changed by the test"
+	languages, err := f.GetLanguages(ctx)
+	assert.NoError(err)
+	languages[0].SpokenIn[0] = "Changed by the caller"
+	languages, err = f.GetLanguages(ctx)
+	assert.NoError(err)
+	assert.Equal([]string{"IN"}, languages[0].SpokenIn)
+}
+
+func TestFake(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	f := NewFake().
+		AddMovie(NewMovie("m1", "Movie One").Build(), NewMovie("m2", "Movie Two").Build()).
+		AddPerson(NewPerson("p1", "Person One").Build()).
+		AddEntity(NewEntity("e1", "Entity One").Build()).
+		AddCertifications(moviebuff.Certification{Code: "UA", Country: moviebuff.Country{Code: "IN"}}).
+		AddHolidayCalendar("IN", &moviebuff.Calendar{Name: "India"}).
+		AddLanguages(moviebuff.Language{Name: "Hindi"}).
+		AddMappedCPL(&moviebuff.MappedCPL{ID: 7, UUID: "cpl"})
+
+	var mb moviebuff.Moviebuff = f
+
+	m, err := mb.GetMovie(ctx, "movie-one")
+	assert.NoError(err)
+	assert.Equal("m1", m.UUID)
+	m.Name = "changed"
+	m, _ = mb.GetMovie(ctx, "m1")
+	assert.Equal("Movie One", m.Name)
+	_, err = mb.GetMovie(ctx, "unknown")
+	assert.Equal(moviebuff.ErrResourceDoesNotExist, err)
+
+	_, err = mb.GetPerson(ctx, "person-one")
+	assert.NoError(err)
+	_, err = mb.GetEntity(ctx, "e1")
+	assert.NoError(err)
+
+	resources, err := mb.GetResources(ctx, moviebuff.RESOURCE_TYPE_MOVIES, 1, 2)
+	assert.NoError(err)
+	assert.Equal("Movie Two", resources.Data[0].Name)
+	assert.Equal("/resources/movies?limit=1&page=1", resources.Prev)
+	assert.Equal("", resources.Next)
+
+	certifications, err := mb.GetCertifications(ctx, "in")
+	assert.NoError(err)
+	assert.Len(certifications, 1)
+	_, err = mb.GetHolidayCalendar(ctx, "IN")
+	assert.NoError(err)
+	languages, err := mb.GetLanguages(ctx)
+	assert.NoError(err)
+	assert.Len(languages, 1)
+	cpl, err := mb.GetMappedCPL(ctx, "7")
+	assert.NoError(err)
+	assert.Equal("cpl", cpl.UUID)
+
+	assert.Equal([]string{"movie-one", "m1", "unknown"}, f.IDs(METHOD_GET_MOVIE))
+	assert.Equal(Call{Method: METHOD_GET_RESOURCES, Args: []string{"movies", "1", "2"}}, f.CallsTo(METHOD_GET_RESOURCES)[0])
+	assert.Len(f.Calls(), 10)
+
+	f.ResetCalls()
+	boom := errors.New("boom")
+	f.Script(METHOD_GET_MOVIE, nil, boom).FailOn(METHOD_GET_PERSON, "p1", moviebuff.ErrInvalidToken)
+
+	_, err = mb.GetMovie(ctx, "m1")
+	assert.NoError(err)
+	_, err = mb.GetMovie(ctx, "m1")
+	assert.Equal(boom, err)
+	_, err = mb.GetMovie(ctx, "m1")
+	assert.NoError(err)
+
+	for i := 0; i < 2; i++ {
+		_, err = mb.GetPerson(ctx, "p1")
+		assert.Equal(moviebuff.ErrInvalidToken, err)
+	}
+	f.FailOn(METHOD_GET_PERSON, "p1", nil)
+	_, err = mb.GetPerson(ctx, "p1")
+	assert.NoError(err)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = mb.GetLanguages(cancelled)
+	assert.Equal(context.Canceled, err)
+
+	assert.Equal([]string{"m1", "m1", "m1"}, f.IDs(METHOD_GET_MOVIE))
+	assert.Len(f.Calls(), 7)
+}
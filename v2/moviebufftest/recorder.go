@@ -0,0 +1,247 @@
+package moviebufftest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Mode of a Recorder.
+type Mode int
+
+const (
+	// MODE_REPLAY serves the interactions of the cassette and never sends requests.
+	MODE_REPLAY Mode = iota
+
+	// MODE_RECORD sends requests and records the interactions in the cassette, replacing its previous content.
+	MODE_RECORD
+
+	// MODE_AUTO replays the cassette if it exists, and records it otherwise.
+	MODE_AUTO
+)
+
+// Value the API key is replaced with in cassettes.
+const SCRUBBED = "[SCRUBBED]"
+
+// ErrUnmatchedRequest is returned when replaying a request no recorded interaction matches.
+// It is wrapped with the method and URL of the request.
+var ErrUnmatchedRequest = errors.New("moviebufftest: no recorded interaction matches the request")
+
+// Cassette is the list of interactions recorded by a Recorder.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a request and the response it received.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a recorded request. Its X-Api-Key header is scrubbed.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+}
+
+// RecordedResponse is a recorded response.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// Recorder is an http.RoundTripper recording interactions with the API to a cassette file and replaying them,
+// so that integration tests run without network access once recorded:
+//
+//	r, err := moviebufftest.NewRecorder("testdata/movie.json", moviebufftest.MODE_AUTO)
+//	mb := moviebuff.New(moviebuff.Config{HostURL: host, StaticToken: token, Client: r.Client()})
+//
+// Requests are matched with interactions on their method, path and query, whatever the order of the query parameters.
+// Matching interactions are replayed in the order they were recorded, the last one being replayed again
+// once all were. A request matching no interaction fails with ErrUnmatchedRequest.
+//
+// A Recorder is safe for concurrent use.
+type Recorder struct {
+	// Transport sends the requests being recorded. Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+
+	mode Mode
+	path string
+
+	mu       sync.Mutex
+	cassette Cassette
+	replayed map[int]bool
+}
+
+// NewRecorder returns a recorder of the cassette stored at path. In replay mode the cassette must exist.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		mode:     mode,
+		path:     path,
+		replayed: map[int]bool{},
+	}
+
+	if mode == MODE_AUTO {
+		r.mode = MODE_RECORD
+		if _, err := os.Stat(path); err == nil {
+			r.mode = MODE_REPLAY
+		}
+	}
+
+	if r.mode == MODE_REPLAY {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("moviebufftest: %s: %v", path, err)
+		}
+	}
+	return r, nil
+}
+
+// Mode returns the mode of the recorder, MODE_RECORD or MODE_REPLAY.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an HTTP client using the recorder as transport, to be set in moviebuff.Config.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Interactions returns the interactions of the cassette.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// RoundTrip replays or records a request, depending on the mode of the recorder.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == MODE_REPLAY {
+		return r.replay(req)
+	}
+	return r.record(req)
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+	for i, in := range r.cassette.Interactions {
+		if !matches(in.Request, req) {
+			continue
+		}
+		match = i
+		if !r.replayed[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrUnmatchedRequest, req.Method, req.URL)
+	}
+	r.replayed[match] = true
+
+	recorded := r.cassette.Interactions[match].Response
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader([]byte(recorded.Body))),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// matches reports whether a request matches a recorded request on its method, path and query.
+func matches(recorded RecordedRequest, req *http.Request) bool {
+	if recorded.Method != req.Method || recorded.Path != req.URL.Path {
+		return false
+	}
+	return normalizeQuery(recorded.Query) == normalizeQuery(req.URL.RawQuery)
+}
+
+// normalizeQuery returns a query with its parameters sorted by key.
+func normalizeQuery(query string) string {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return query
+	}
+	return values.Encode()
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	header := req.Header.Clone()
+	if header.Get(API_KEY_HEADER) != "" {
+		header.Set(API_KEY_HEADER, SCRUBBED)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  req.URL.RawQuery,
+			Header: header,
+		},
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+			Header:     res.Header.Clone(),
+			Body:       string(body),
+		},
+	})
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// save writes the cassette, so that it is complete even if the test stops early. r.mu must be held.
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,81 @@
+package moviebufftest
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "cassettes")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "testdata", "cassette.json")
+
+	_, err = NewRecorder(path, MODE_REPLAY)
+	assert.True(os.IsNotExist(err))
+
+	s := NewServer("secret-token")
+	assert.NoError(s.AddMovie(NewMovie("m1", "Movie One").Build()))
+	assert.NoError(s.AddMovie(NewMovie("m2", "Movie Two").Build()))
+
+	recorder, err := NewRecorder(path, MODE_AUTO)
+	assert.NoError(err)
+	assert.Equal(MODE_RECORD, recorder.Mode())
+
+	mb := moviebuff.New(moviebuff.Config{HostURL: s.URL, StaticToken: "secret-token", Client: recorder.Client()})
+	m, err := mb.GetMovie(ctx, "m1")
+	assert.NoError(err)
+	assert.Equal("Movie One", m.Name)
+	_, err = mb.GetMovie(ctx, "unknown")
+	assert.Equal(moviebuff.ErrResourceDoesNotExist, err)
+	resources, err := mb.GetResources(ctx, moviebuff.RESOURCE_TYPE_MOVIES, 1, 2)
+	assert.NoError(err)
+	assert.Equal("Movie Two", resources.Data[0].Name)
+	s.Close()
+
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(err)
+	assert.False(strings.Contains(string(data), "secret-token"))
+	assert.True(strings.Contains(string(data), SCRUBBED))
+	assert.Len(recorder.Interactions(), 3)
+
+	recorder, err = NewRecorder(path, MODE_AUTO)
+	assert.NoError(err)
+	assert.Equal(MODE_REPLAY, recorder.Mode())
+
+	mb = moviebuff.New(moviebuff.Config{HostURL: s.URL, StaticToken: "another-token", Client: recorder.Client()})
+	for i := 0; i < 2; i++ {
+		m, err = mb.GetMovie(ctx, "m1")
+		assert.NoError(err)
+		assert.Equal("Movie One", m.Name)
+	}
+	_, err = mb.GetMovie(ctx, "unknown")
+	assert.Equal(moviebuff.ErrResourceDoesNotExist, err)
+
+	// Query parameters match whatever their order.
+	req, err := http.NewRequest(http.MethodGet, s.URL+"/resources/movies?page=2&limit=1", nil)
+	assert.NoError(err)
+	res, err := recorder.Client().Do(req)
+	assert.NoError(err)
+	body, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.True(strings.Contains(string(body), "Movie Two"))
+
+	_, err = mb.GetResources(ctx, moviebuff.RESOURCE_TYPE_MOVIES, 1, 1)
+	assert.True(errors.Is(err, ErrUnmatchedRequest))
+	_, err = mb.GetMovie(ctx, "m2")
+	assert.True(errors.Is(err, ErrUnmatchedRequest))
+}
@@ -0,0 +1,581 @@
+// Package moviebufftest provides fakes of the Moviebuff API for tests.
+//
+// Fake is an in-memory implementation of the Moviebuff interface, for unit tests of code using the interface,
+// and builders construct Movie, Person and Entity fixtures fluently.
+//
+// Server is a fake of the HTTP API, for tests going through the HTTP client. It serves movies, people,
+// entities, paginated resources, certifications, holiday calendars, languages and mapped CPLs from fixtures,
+// rejects requests without the expected X-Api-Key, and can inject latency, errors, rate limiting
+// and malformed JSON per route:
+//
+//	s := moviebufftest.NewServer("token")
+//	defer s.Close()
+//
+//	s.AddMovie(&moviebuff.Movie{UUID: "uuid", URL: "12-years-a-slave", Name: "12 Years a Slave"})
+//	s.Inject(moviebufftest.ROUTE_MOVIE, moviebufftest.Fault{Status: http.StatusTooManyRequests, Times: 1})
+//
+//	mb := s.Moviebuff()
+//
+// Recorder records interactions with the real API to cassette files and replays them, for integration tests
+// running without network access.
+package moviebufftest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+)
+
+// Routes of the API, used to inject faults and count requests
+const (
+	ROUTE_MOVIE          = "movie"
+	ROUTE_PERSON         = "person"
+	ROUTE_ENTITY         = "entity"
+	ROUTE_RESOURCES      = "resources"
+	ROUTE_CERTIFICATIONS = "certifications"
+	ROUTE_HOLIDAYS       = "holidays"
+	ROUTE_LANGUAGES      = "languages"
+	ROUTE_MAPPED_CPL     = "mapped_cpl"
+)
+
+// Header the API key is sent in.
+const API_KEY_HEADER = "X-Api-Key"
+
+// Pagination of resources
+const (
+	DEFAULT_LIMIT = 20
+	MAX_LIMIT     = 50
+)
+
+// Fault is a misbehaviour injected in the responses of a route.
+type Fault struct {
+	// Latency delays the response.
+	Latency time.Duration
+
+	// Status responds with the given status code and no body instead of the fixture.
+	Status int
+
+	// RetryAfter is sent as the Retry-After header, in seconds, with a status of 429 Too Many Requests.
+	RetryAfter time.Duration
+
+	// MalformedJSON responds with a truncated body.
+	MalformedJSON bool
+
+	// Times is the number of requests the fault applies to. It applies to every request if zero.
+	Times int
+}
+
+// Server is a fake Moviebuff API. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	// Token is the API key requests must send. Requests with another key are answered with 403 Forbidden.
+	Token string
+
+	mu             sync.Mutex
+	resources      map[string]*fixtures
+	certifications []moviebuff.Certification
+	calendars      map[string]*moviebuff.Calendar
+	languages      []moviebuff.Language
+	mappedCPLs     map[string]*moviebuff.MappedCPL
+	faults         map[string][]*Fault
+	requests       map[string]int
+}
+
+// fixtures of a type of resource, in order of addition and indexed by UUID and URL.
+type fixtures struct {
+	list []json.RawMessage
+	byID map[string]json.RawMessage
+
+	// positions maps a UUID to the position of its fixture in list.
+	positions map[string]int
+}
+
+// Type of resources, as returned in their type field
+const (
+	typeMovie  = "movie"
+	typePerson = "person"
+	typeEntity = "entity"
+)
+
+// NewServer starts a fake API expecting token as API key. Close it when done.
+func NewServer(token string) *Server {
+	s := &Server{
+		Token: token,
+		resources: map[string]*fixtures{
+			typeMovie:  {byID: map[string]json.RawMessage{}, positions: map[string]int{}},
+			typePerson: {byID: map[string]json.RawMessage{}, positions: map[string]int{}},
+			typeEntity: {byID: map[string]json.RawMessage{}, positions: map[string]int{}},
+		},
+		calendars:  map[string]*moviebuff.Calendar{},
+		mappedCPLs: map[string]*moviebuff.MappedCPL{},
+		faults:     map[string][]*Fault{},
+		requests:   map[string]int{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Moviebuff returns a client of the server using its token.
+func (s *Server) Moviebuff() moviebuff.Moviebuff {
+	return moviebuff.New(moviebuff.Config{
+		HostURL:     s.URL,
+		StaticToken: s.Token,
+		Client:      s.Client(),
+	})
+}
+
+// AddMovie adds a movie, served by its UUID and its URL. The fixture is a *moviebuff.Movie,
+// or its JSON as a string, []byte or json.RawMessage. Its type is set to "movie" if missing.
+// Adding a movie with the UUID of one added before replaces it.
+func (s *Server) AddMovie(fixture interface{}) error {
+	return s.add(typeMovie, fixture)
+}
+
+// AddPerson adds a person, served by its UUID and its URL. See AddMovie.
+func (s *Server) AddPerson(fixture interface{}) error {
+	return s.add(typePerson, fixture)
+}
+
+// AddEntity adds an entity, served by its UUID and its URL. See AddMovie.
+func (s *Server) AddEntity(fixture interface{}) error {
+	return s.add(typeEntity, fixture)
+}
+
+func (s *Server) add(resourceType string, fixture interface{}) error {
+	var data []byte
+	switch f := fixture.(type) {
+	case string:
+		data = []byte(f)
+	case []byte:
+		data = f
+	case json.RawMessage:
+		data = f
+	default:
+		var err error
+		if data, err = json.Marshal(f); err != nil {
+			return err
+		}
+	}
+
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if t, _ := fields["type"].(string); t == "" {
+		fields["type"] = resourceType
+	}
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f := s.resources[resourceType]
+	uuid, _ := fields["uuid"].(string)
+	if i, ok := f.positions[uuid]; ok && uuid != "" {
+		var old struct {
+			URL string `json:"url"`
+		}
+		if err := json.Unmarshal(f.list[i], &old); err == nil {
+			delete(f.byID, old.URL)
+		}
+		f.list[i] = raw
+	} else {
+		if uuid != "" {
+			f.positions[uuid] = len(f.list)
+		}
+		f.list = append(f.list, raw)
+	}
+	for _, key := range []string{"uuid", "url"} {
+		if id, _ := fields[key].(string); id != "" {
+			f.byID[id] = raw
+		}
+	}
+	return nil
+}
+
+// AddCertifications adds certifications, filtered by the code or UUID of their country when requested for a country.
+func (s *Server) AddCertifications(certifications ...moviebuff.Certification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.certifications = append(s.certifications, certifications...)
+}
+
+// AddHolidayCalendar adds the holiday calendar of the country with the given ID.
+func (s *Server) AddHolidayCalendar(countryID string, c *moviebuff.Calendar) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calendars[countryID] = c
+}
+
+// AddLanguages adds languages.
+func (s *Server) AddLanguages(languages ...moviebuff.Language) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.languages = append(s.languages, languages...)
+}
+
+// AddMappedCPL adds a mapped CPL, served by its UUID and its ID.
+func (s *Server) AddMappedCPL(c *moviebuff.MappedCPL) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mappedCPLs[c.UUID] = c
+	s.mappedCPLs[strconv.Itoa(c.ID)] = c
+}
+
+// LoadFixtures adds the fixtures stored in dir, laid out as:
+//
+//	movies/*.json, people/*.json, entities/*.json  one resource per file
+//	certifications.json                            an array of certifications
+//	holidays/<country ID>.json                     a calendar per file
+//	languages.json                                 an array of languages
+//	mapped_cpls/*.json                             a mapped CPL per file
+//
+// Missing files and directories are skipped.
+func (s *Server) LoadFixtures(dir string) error {
+	for sub, add := range map[string]func(interface{}) error{
+		"movies":   s.AddMovie,
+		"people":   s.AddPerson,
+		"entities": s.AddEntity,
+	} {
+		err := readFixtures(filepath.Join(dir, sub), func(name string, data []byte) error {
+			return add(data)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	var certifications []moviebuff.Certification
+	if err := readFixture(filepath.Join(dir, "certifications.json"), &certifications); err != nil {
+		return err
+	}
+	s.AddCertifications(certifications...)
+
+	var languages []moviebuff.Language
+	if err := readFixture(filepath.Join(dir, "languages.json"), &languages); err != nil {
+		return err
+	}
+	s.AddLanguages(languages...)
+
+	err := readFixtures(filepath.Join(dir, "holidays"), func(name string, data []byte) error {
+		c := new(moviebuff.Calendar)
+		if err := json.Unmarshal(data, c); err != nil {
+			return err
+		}
+		s.AddHolidayCalendar(strings.TrimSuffix(name, filepath.Ext(name)), c)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return readFixtures(filepath.Join(dir, "mapped_cpls"), func(name string, data []byte) error {
+		c := new(moviebuff.MappedCPL)
+		if err := json.Unmarshal(data, c); err != nil {
+			return err
+		}
+		s.AddMappedCPL(c)
+		return nil
+	})
+}
+
+// readFixture decodes the JSON file at path into v, unless the file does not exist.
+func readFixture(path string, v interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("moviebufftest: %s: %v", path, err)
+	}
+	return nil
+}
+
+// readFixtures calls fn with every JSON file of dir, in lexical order, unless dir does not exist.
+func readFixtures(dir string, fn func(name string, data []byte) error) error {
+	infos, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, info := range infos {
+		if info.IsDir() || filepath.Ext(info.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(dir, info.Name())
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := fn(info.Name(), data); err != nil {
+			return fmt.Errorf("moviebufftest: %s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+// Inject adds a fault to the responses of a route. Faults of a route apply in the order they were injected,
+// a fault applying to a number of requests being dropped once they were answered.
+func (s *Server) Inject(route string, f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults[route] = append(s.faults[route], &f)
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = map[string][]*Fault{}
+}
+
+// Requests returns the number of requests received by a route, including the rejected ones.
+func (s *Server) Requests(route string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[route]
+}
+
+// count counts a request of the route.
+func (s *Server) count(route string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests[route]++
+}
+
+// fault returns the fault to apply to a request of the route, if any.
+func (s *Server) fault(route string) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	faults := s.faults[route]
+	if len(faults) == 0 {
+		return nil
+	}
+
+	f := *faults[0]
+	if faults[0].Times > 0 {
+		faults[0].Times--
+		if faults[0].Times == 0 {
+			s.faults[route] = faults[1:]
+		}
+	}
+	return &f
+}
+
+// route returns the route of a path and the ID it addresses, if any.
+func route(path string) (string, string) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) == 3 && parts[0] == "resources":
+		switch parts[1] {
+		case string(moviebuff.RESOURCE_TYPE_MOVIES):
+			return ROUTE_MOVIE, parts[2]
+		case string(moviebuff.RESOURCE_TYPE_PEOPLE):
+			return ROUTE_PERSON, parts[2]
+		case string(moviebuff.RESOURCE_TYPE_ENTITIES):
+			return ROUTE_ENTITY, parts[2]
+		}
+	case len(parts) == 2 && parts[0] == "resources":
+		return ROUTE_RESOURCES, parts[1]
+	case len(parts) == 1 && parts[0] == "certifications":
+		return ROUTE_CERTIFICATIONS, ""
+	case len(parts) == 2 && parts[0] == "holidays":
+		return ROUTE_HOLIDAYS, parts[1]
+	case len(parts) == 1 && parts[0] == "languages":
+		return ROUTE_LANGUAGES, ""
+	case len(parts) == 2 && parts[0] == "mapped_cpls":
+		return ROUTE_MAPPED_CPL, parts[1]
+	}
+	return "", ""
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	name, id := route(r.URL.Path)
+	if name == "" || r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	s.count(name)
+	if r.Header.Get(API_KEY_HEADER) != s.Token {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error": "forbidden"}`))
+		return
+	}
+
+	// Faults only apply to authorised requests, so that rejected requests do not use them up.
+	f := s.fault(name)
+	if f != nil && f.Latency > 0 {
+		select {
+		case <-time.After(f.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if f != nil && f.Status != 0 {
+		if f.Status == http.StatusTooManyRequests && f.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(f.RetryAfter.Seconds())))
+		}
+		w.WriteHeader(f.Status)
+		return
+	}
+
+	body, status := s.respond(name, id, r)
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+		return
+	}
+	if f != nil && f.MalformedJSON {
+		body = body[:len(body)/2]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// respond returns the body of a successful response to a request of a route, or the status of the failure.
+func (s *Server) respond(name, id string, r *http.Request) ([]byte, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var v interface{}
+	switch name {
+	case ROUTE_MOVIE, ROUTE_PERSON, ROUTE_ENTITY:
+		raw, ok := s.resources[name].byID[id]
+		if !ok {
+			return nil, http.StatusNotFound
+		}
+		return raw, http.StatusOK
+
+	case ROUTE_RESOURCES:
+		resources, status := s.page(id, r)
+		if status != http.StatusOK {
+			return nil, status
+		}
+		v = resources
+
+	case ROUTE_CERTIFICATIONS:
+		country := r.URL.Query().Get("country")
+		certifications := []moviebuff.Certification{}
+		for _, c := range s.certifications {
+			if country == "" || strings.EqualFold(c.Country.Code, country) || c.Country.UUID == country {
+				certifications = append(certifications, c)
+			}
+		}
+		v = struct {
+			Data []moviebuff.Certification `json:"data"`
+		}{certifications}
+
+	case ROUTE_HOLIDAYS:
+		c, ok := s.calendars[id]
+		if !ok {
+			return nil, http.StatusNotFound
+		}
+		v = c
+
+	case ROUTE_LANGUAGES:
+		languages := s.languages
+		if languages == nil {
+			languages = []moviebuff.Language{}
+		}
+		v = languages
+
+	case ROUTE_MAPPED_CPL:
+		c, ok := s.mappedCPLs[id]
+		if !ok {
+			return nil, http.StatusNotFound
+		}
+		v = c
+	}
+
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, http.StatusInternalServerError
+	}
+	return body, http.StatusOK
+}
+
+// page returns a page of the resources of a type. The limit is capped at MAX_LIMIT and pages start at 1.
+func (s *Server) page(resourceType string, r *http.Request) (*moviebuff.Resources, int) {
+	var f *fixtures
+	switch moviebuff.ResourceType(resourceType) {
+	case moviebuff.RESOURCE_TYPE_MOVIES:
+		f = s.resources[typeMovie]
+	case moviebuff.RESOURCE_TYPE_PEOPLE:
+		f = s.resources[typePerson]
+	case moviebuff.RESOURCE_TYPE_ENTITIES:
+		f = s.resources[typeEntity]
+	default:
+		return nil, http.StatusNotFound
+	}
+
+	limit, page := DEFAULT_LIMIT, 1
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return nil, http.StatusBadRequest
+		}
+		limit = n
+	}
+	if limit > MAX_LIMIT {
+		limit = MAX_LIMIT
+	}
+	if v := r.URL.Query().Get("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return nil, http.StatusBadRequest
+		}
+		page = n
+	}
+
+	resources := &moviebuff.Resources{Data: []moviebuff.Resource{}}
+	for i := (page - 1) * limit; i < page*limit && i < len(f.list); i++ {
+		var res moviebuff.Resource
+		if err := json.Unmarshal(f.list[i], &res); err != nil {
+			return nil, http.StatusInternalServerError
+		}
+		resources.Data = append(resources.Data, res)
+	}
+
+	pageURL := func(page int) string {
+		return fmt.Sprintf("/resources/%s?limit=%d&page=%d", resourceType, limit, page)
+	}
+	if page > 1 {
+		resources.Prev = pageURL(page - 1)
+	}
+	if page*limit < len(f.list) {
+		resources.Next = pageURL(page + 1)
+	}
+	return resources, http.StatusOK
+}
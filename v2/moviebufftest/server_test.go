@@ -0,0 +1,208 @@
+package moviebufftest
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	moviebuff "github.com/RealImage/moviebuff-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	s := NewServer("token")
+	defer s.Close()
+
+	assert.NoError(s.AddMovie(&moviebuff.Movie{UUID: "m1", URL: "movie-one", Name: "Movie One"}))
+	assert.NoError(s.AddMovie(`{"uuid": "m2", "url": "movie-two", "name": "Movie Two", "type": "movie"}`))
+	assert.NoError(s.AddMovie([]byte(`{"uuid": "m3", "name": "Movie Three"}`)))
+	assert.NoError(s.AddPerson(&moviebuff.Person{UUID: "p1", Name: "Person One"}))
+	assert.NoError(s.AddEntity(&moviebuff.Entity{UUID: "e1", Name: "Entity One"}))
+	assert.Error(s.AddMovie(`not json`))
+	s.AddCertifications(
+		moviebuff.Certification{Code: "UA", Country: moviebuff.Country{Code: "IN", UUID: "in-uuid"}},
+		moviebuff.Certification{Code: "PG", Country: moviebuff.Country{Code: "US"}},
+	)
+	s.AddHolidayCalendar("IN", &moviebuff.Calendar{Name: "India", TimeZone: "Asia/Kolkata",
+		Holidays: []moviebuff.Holiday{{ID: "1", Name: "Diwali", Date: "2024-11-01"}}})
+	s.AddLanguages(moviebuff.Language{UUID: "hi-uuid", Name: "Hindi"})
+	s.AddMappedCPL(&moviebuff.MappedCPL{ID: 42, UUID: "cpl-uuid", Movie: moviebuff.MappedCPLMovie{UUID: "m1"}})
+
+	mb := s.Moviebuff()
+
+	m, err := mb.GetMovie(ctx, "movie-one")
+	assert.NoError(err)
+	assert.Equal("Movie One", m.Name)
+	m, err = mb.GetMovie(ctx, "m3")
+	assert.NoError(err)
+	assert.Equal("movie", m.Type)
+	_, err = mb.GetMovie(ctx, "p1")
+	assert.Equal(moviebuff.ErrResourceDoesNotExist, err)
+
+	p, err := mb.GetPerson(ctx, "p1")
+	assert.NoError(err)
+	assert.Equal("Person One", p.Name)
+	e, err := mb.GetEntity(ctx, "e1")
+	assert.NoError(err)
+	assert.Equal("Entity One", e.Name)
+
+	resources, err := mb.GetResources(ctx, moviebuff.RESOURCE_TYPE_MOVIES, 2, 1)
+	assert.NoError(err)
+	assert.Len(resources.Data, 2)
+	assert.Equal("m1", resources.Data[0].UUID)
+	assert.Equal("", resources.Prev)
+	assert.Equal("/resources/movies?limit=2&page=2", resources.Next)
+	resources, err = mb.GetResources(ctx, moviebuff.RESOURCE_TYPE_MOVIES, 2, 2)
+	assert.NoError(err)
+	assert.Len(resources.Data, 1)
+	assert.Equal("Movie Three", resources.Data[0].Name)
+	assert.Equal("", resources.Next)
+
+	certifications, err := mb.GetCertifications(ctx, "in-uuid")
+	assert.NoError(err)
+	assert.Len(certifications, 1)
+	certifications, err = mb.GetCertifications(ctx, "")
+	assert.NoError(err)
+	assert.Len(certifications, 2)
+
+	c, err := mb.GetHolidayCalendar(ctx, "IN")
+	assert.NoError(err)
+	assert.Equal("Diwali", c.Holidays[0].Name)
+	_, err = mb.GetHolidayCalendar(ctx, "US")
+	assert.Equal(moviebuff.ErrResourceDoesNotExist, err)
+
+	languages, err := mb.GetLanguages(ctx)
+	assert.NoError(err)
+	assert.Equal("Hindi", languages[0].Name)
+
+	cpl, err := mb.GetMappedCPL(ctx, "42")
+	assert.NoError(err)
+	assert.Equal("cpl-uuid", cpl.UUID)
+
+	_, err = moviebuff.New(moviebuff.Config{HostURL: s.URL, StaticToken: "wrong"}).GetMovie(ctx, "m1")
+	assert.Equal(moviebuff.ErrInvalidToken, err)
+	assert.Equal(4, s.Requests(ROUTE_MOVIE))
+
+	// Adding a movie again replaces it.
+	assert.NoError(s.AddMovie(&moviebuff.Movie{UUID: "m1", URL: "first-movie", Name: "First Movie"}))
+	resources, err = mb.GetResources(ctx, moviebuff.RESOURCE_TYPE_MOVIES, 10, 1)
+	assert.NoError(err)
+	assert.Len(resources.Data, 3)
+	assert.Equal("First Movie", resources.Data[0].Name)
+	_, err = mb.GetMovie(ctx, "movie-one")
+	assert.Equal(moviebuff.ErrResourceDoesNotExist, err)
+	m, err = mb.GetMovie(ctx, "first-movie")
+	assert.NoError(err)
+	assert.Equal("m1", m.UUID)
+}
+
+func TestServer_Inject(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	s := NewServer("token")
+	defer s.Close()
+	assert.NoError(s.AddMovie(&moviebuff.Movie{UUID: "m1", Name: "Movie One"}))
+	mb := s.Moviebuff()
+
+	s.Inject(ROUTE_MOVIE, Fault{Status: http.StatusTooManyRequests, RetryAfter: 2 * time.Second, Times: 1})
+	s.Inject(ROUTE_MOVIE, Fault{MalformedJSON: true, Times: 1})
+
+	// Rejected requests do not use up faults.
+	_, err := moviebuff.New(moviebuff.Config{HostURL: s.URL, StaticToken: "wrong"}).GetMovie(ctx, "m1")
+	assert.Equal(moviebuff.ErrInvalidToken, err)
+
+	r, err := http.NewRequest(http.MethodGet, s.URL+"/resources/movies/m1", nil)
+	assert.NoError(err)
+	r.Header.Set(API_KEY_HEADER, "token")
+	res, err := s.Client().Do(r)
+	assert.NoError(err)
+	res.Body.Close()
+	assert.Equal(http.StatusTooManyRequests, res.StatusCode)
+	assert.Equal("2", res.Header.Get("Retry-After"))
+
+	_, err = mb.GetMovie(ctx, "m1")
+	assert.Error(err)
+	assert.NotEqual(moviebuff.ErrResponseNotReceived, err)
+
+	_, err = mb.GetMovie(ctx, "m1")
+	assert.NoError(err)
+
+	s.Inject(ROUTE_LANGUAGES, Fault{Status: http.StatusInternalServerError})
+	for i := 0; i < 2; i++ {
+		_, err = mb.GetLanguages(ctx)
+		assert.Equal(moviebuff.ErrResponseNotReceived, err)
+	}
+
+	s.Inject(ROUTE_MOVIE, Fault{Latency: time.Second})
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = mb.GetMovie(timeout, "m1")
+	assert.Error(err)
+
+	s.ClearFaults()
+	_, err = mb.GetLanguages(ctx)
+	assert.NoError(err)
+	assert.Equal(5, s.Requests(ROUTE_MOVIE))
+}
+
+func TestServer_LoadFixtures(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "fixtures")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	for name, content := range map[string]string{
+		"movies/one.json":          `{"uuid": "m1", "url": "movie-one", "name": "Movie One"}`,
+		"people/one.json":          `{"uuid": "p1", "name": "Person One"}`,
+		"languages.json":           `[{"uuid": "ta-uuid", "name": "Tamil"}]`,
+		"certifications.json":      `[{"code": "U", "country": {"code": "IN"}}]`,
+		"holidays/IN.json":         `{"name": "India", "holidays": [{"id": "1", "name": "Pongal", "date": "2024-01-15"}]}`,
+		"mapped_cpls/one.json":     `{"id": 1, "uuid": "cpl-uuid", "movie": {"uuid": "m1"}}`,
+		"mapped_cpls/README.md":    `ignored`,
+		"entities/.gitkeep":        ``,
+		"holidays/ignored/US.json": `not json`,
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoError(os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	s := NewServer("token")
+	defer s.Close()
+	assert.NoError(s.LoadFixtures(dir))
+	mb := s.Moviebuff()
+
+	m, err := mb.GetMovie(ctx, "movie-one")
+	assert.NoError(err)
+	assert.Equal("Movie One", m.Name)
+	p, err := mb.GetPerson(ctx, "p1")
+	assert.NoError(err)
+	assert.Equal("Person One", p.Name)
+	languages, err := mb.GetLanguages(ctx)
+	assert.NoError(err)
+	assert.Equal("Tamil", languages[0].Name)
+	certifications, err := mb.GetCertifications(ctx, "IN")
+	assert.NoError(err)
+	assert.Equal("U", certifications[0].Code)
+	c, err := mb.GetHolidayCalendar(ctx, "IN")
+	assert.NoError(err)
+	assert.Equal("Pongal", c.Holidays[0].Name)
+	cpl, err := mb.GetMappedCPL(ctx, "cpl-uuid")
+	assert.NoError(err)
+	assert.Equal("m1", cpl.Movie.UUID)
+
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, "languages.json"), []byte(`{`), 0644))
+	broken := NewServer("token")
+	defer broken.Close()
+	assert.Error(broken.LoadFixtures(dir))
+}
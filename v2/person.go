@@ -57,33 +57,13 @@ type Person struct {
 	Trivia []string `json:"trivia"`
 
 	// An list of posters of the given person.
-	Posters []struct {
-		Featured bool   `json:"featured"`
-		URL      string `json:"url"`
-		Key      string `json:"key"`
-		Caption  string `json:"caption"`
-		Type     string `json:"type"`
-	} `json:"posters"`
+	Posters []Image `json:"posters"`
 
 	// An list of videos of the given person.
-	Videos []struct {
-		Featured  bool   `json:"featured"`
-		URL       string `json:"url"`
-		EmbedURL  string `json:"embedUrl"`
-		Key       string `json:"key"`
-		Caption   string `json:"caption"`
-		Thumbnail string `json:"thumbnail"`
-		Type      string `json:"type"`
-	} `json:"videos"`
+	Videos []Video `json:"videos"`
 
 	// An list of stills of the given person.
-	Stills []struct {
-		Featured bool   `json:"featured"`
-		URL      string `json:"url"`
-		Key      string `json:"key"`
-		Caption  string `json:"caption"`
-		Type     string `json:"type"`
-	} `json:"stills"`
+	Stills []Image `json:"stills"`
 
 	// The roles of the person in various movies grouped by department name.
 	Credits []struct {
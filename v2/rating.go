@@ -0,0 +1,110 @@
+package moviebuff
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Rating contains the value and the count of the ratings.
+// The API returns the value as a string, use Float to get its numeric value.
+type Rating struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// Float returns the numeric value of the rating.
+// ok is false if the value is missing or malformed.
+func (r Rating) Float() (value float64, ok bool) {
+	s := strings.TrimSpace(r.Value)
+	if s == "" {
+		return 0, false
+	}
+
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false
+	}
+	return value, true
+}
+
+// Valid reports whether the rating has a numeric value.
+func (r Rating) Valid() bool {
+	_, ok := r.Float()
+	return ok
+}
+
+// WeightedRating returns the average of the given ratings weighted by their count.
+// Ratings which are missing, malformed or have no count are ignored.
+// ok is false if none of the ratings could be used.
+func WeightedRating(ratings ...Rating) (value float64, ok bool) {
+	var sum float64
+	var count int
+	for _, r := range ratings {
+		v, valid := r.Float()
+		if !valid || r.Count <= 0 {
+			continue
+		}
+		sum += v * float64(r.Count)
+		count += r.Count
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return sum / float64(count), true
+}
+
+// CompareRatings compares two ratings by value and then by count.
+// Ratings which are missing or malformed are lower than any valid rating.
+// The result is -1 if a < b, 0 if a == b and +1 if a > b.
+func CompareRatings(a, b Rating) int {
+	av, aok := a.Float()
+	bv, bok := b.Float()
+	switch {
+	case aok && !bok:
+		return 1
+	case !aok && bok:
+		return -1
+	case av < bv:
+		return -1
+	case av > bv:
+		return 1
+	case a.Count < b.Count:
+		return -1
+	case a.Count > b.Count:
+		return 1
+	}
+	return 0
+}
+
+// GetCombinedRating returns the average of the movie and music ratings weighted by their count.
+// ok is false if neither rating is available
+func (m *Movie) GetCombinedRating() (value float64, ok bool) {
+	return WeightedRating(m.MovieRating, m.MusicRating)
+}
+
+// SortMoviesByRating sorts movies by their movie rating, highest first.
+// Movies with equal ratings are ordered by rating count and then by name.
+// Movies without a valid rating are moved to the end.
+func SortMoviesByRating(movies []*Movie) {
+	sort.SliceStable(movies, func(i, j int) bool {
+		return lessByRating(movies[i], movies[j], movies[i].MovieRating, movies[j].MovieRating)
+	})
+}
+
+// SortMoviesByMusicRating sorts movies by their music rating, highest first.
+// Movies with equal ratings are ordered by rating count and then by name.
+// Movies without a valid rating are moved to the end.
+func SortMoviesByMusicRating(movies []*Movie) {
+	sort.SliceStable(movies, func(i, j int) bool {
+		return lessByRating(movies[i], movies[j], movies[i].MusicRating, movies[j].MusicRating)
+	})
+}
+
+func lessByRating(a, b *Movie, ar, br Rating) bool {
+	if c := CompareRatings(ar, br); c != 0 {
+		return c > 0
+	}
+	return a.Name < b.Name
+}
@@ -0,0 +1,88 @@
+package moviebuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRating_Float(t *testing.T) {
+	var testCases = []struct {
+		desc          string
+		rating        Rating
+		expectedValue float64
+		expectedOK    bool
+	}{
+		{
+			desc:          "valid rating",
+			rating:        Rating{Value: "4.5", Count: 10},
+			expectedValue: 4.5,
+			expectedOK:    true,
+		}, {
+			desc:          "surrounding spaces",
+			rating:        Rating{Value: " 3 ", Count: 10},
+			expectedValue: 3,
+			expectedOK:    true,
+		}, {
+			desc:   "missing rating",
+			rating: Rating{},
+		}, {
+			desc:   "malformed rating",
+			rating: Rating{Value: "four", Count: 10},
+		}, {
+			desc:   "not a number",
+			rating: Rating{Value: "NaN", Count: 10},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			value, ok := testCase.rating.Float()
+			assert.Equal(testCase.expectedValue, value)
+			assert.Equal(testCase.expectedOK, ok)
+		})
+	}
+}
+
+func TestMovie_GetCombinedRating(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &Movie{
+		MovieRating: Rating{Value: "4", Count: 30},
+		MusicRating: Rating{Value: "2", Count: 10},
+	}
+	value, ok := m.GetCombinedRating()
+	assert.True(ok)
+	assert.Equal(3.5, value)
+
+	m.MusicRating = Rating{Value: "bad", Count: 10}
+	value, ok = m.GetCombinedRating()
+	assert.True(ok)
+	assert.Equal(4.0, value)
+
+	m.MovieRating = Rating{Value: "4", Count: 0}
+	_, ok = m.GetCombinedRating()
+	assert.False(ok)
+}
+
+func TestSortMoviesByRating(t *testing.T) {
+	assert := assert.New(t)
+
+	movies := []*Movie{
+		{Name: "Unrated"},
+		{Name: "Low", MovieRating: Rating{Value: "2.5", Count: 100}},
+		{Name: "Popular", MovieRating: Rating{Value: "4", Count: 500}},
+		{Name: "Malformed", MovieRating: Rating{Value: "n/a", Count: 5}},
+		{Name: "Niche", MovieRating: Rating{Value: "4", Count: 20}},
+		{Name: "Best", MovieRating: Rating{Value: "4.8", Count: 20}},
+	}
+	SortMoviesByRating(movies)
+
+	var names []string
+	for _, m := range movies {
+		names = append(names, m.Name)
+	}
+	assert.Equal([]string{"Best", "Popular", "Niche", "Low", "Malformed", "Unrated"}, names)
+}
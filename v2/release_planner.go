@@ -0,0 +1,256 @@
+package moviebuff
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReleaseScoreWeights tunes how a ReleasePlanner scores candidate release dates.
+// Positive weights make a factor attractive, negative weights make it something to avoid.
+type ReleaseScoreWeights struct {
+	// Holiday is added for every holiday within HolidayWindow days of the release date,
+	// scaled down linearly with its distance from the release date.
+	Holiday       float64
+	HolidayWindow int
+
+	// LongWeekend is added when the opening weekend, the release date and the two days after it,
+	// overlaps a long weekend.
+	LongWeekend float64
+
+	// Competition is added for every movie in the same language releasing
+	// within CompetitionWindow days of the release date.
+	Competition       float64
+	CompetitionWindow int
+}
+
+// defaultReleaseScoreWeights avoids clashing with holidays and competing releases, but favours long weekends.
+var defaultReleaseScoreWeights = ReleaseScoreWeights{
+	Holiday:           -1,
+	HolidayWindow:     3,
+	LongWeekend:       2,
+	Competition:       -1.5,
+	CompetitionWindow: 7,
+}
+
+// DefaultReleaseScoreWeights returns the weights of new planners, which avoid clashing with holidays
+// and competing releases, but favour long weekends.
+func DefaultReleaseScoreWeights() ReleaseScoreWeights {
+	return defaultReleaseScoreWeights
+}
+
+// ReleasePlanner suggests release dates using the holiday calendars of a set of countries
+// and the release dates of already known movies.
+type ReleasePlanner struct {
+	// Weights used to score release dates. Defaults to DefaultReleaseScoreWeights().
+	Weights ReleaseScoreWeights
+
+	countries []string
+	calendars map[string]*BusinessCalendar
+	movies    []*Movie
+}
+
+// ReleasePlanRequest describes the release dates to consider.
+type ReleasePlanRequest struct {
+	// From and To are the first and last candidate days, both inclusive.
+	From time.Time
+	To   time.Time
+
+	// Language of the movie being released. Only movies in the same language compete with it.
+	// Compared case insensitively. Every movie competes if empty.
+	Language string
+
+	// Weekdays on which the movie may be released. Defaults to Friday.
+	Weekdays []time.Weekday
+
+	// ExcludeMovie is the UUID of the movie being planned, so that its own release dates do not compete with it.
+	ExcludeMovie string
+
+	// Limit is the maximum number of suggestions returned. All candidates are returned if zero.
+	Limit int
+}
+
+// ReleaseSuggestion is a candidate release date and its score.
+type ReleaseSuggestion struct {
+	// Date is the candidate release day, at midnight UTC.
+	Date  time.Time
+	Score float64
+
+	// Holidays near the release date, mapped against their country code.
+	Holidays map[string][]Holiday
+
+	// LongWeekends overlapping the opening weekend, mapped against their country code.
+	LongWeekends map[string]LongWeekend
+
+	// Competing movies releasing around the release date, mapped against their country code.
+	Competing map[string][]*Movie
+
+	// Reasons explain the score in human readable form.
+	Reasons []string
+}
+
+// NewReleasePlanner fetches the holiday calendars of the given countries and returns a planner
+// comparing release dates against movies. Countries are the ISO 2-digit codes used in Movie.ReleaseDates,
+// they are passed as is to GetHolidayCalendar.
+func NewReleasePlanner(ctx context.Context, mb Moviebuff, countries []string, movies []*Movie) (*ReleasePlanner, error) {
+	calendars := make(map[string]*Calendar, len(countries))
+	for _, country := range countries {
+		c, err := mb.GetHolidayCalendar(ctx, country)
+		if err != nil {
+			return nil, err
+		}
+		calendars[country] = c
+	}
+	return NewReleasePlannerFromCalendars(calendars, movies)
+}
+
+// NewReleasePlannerFromCalendars returns a planner using already fetched calendars mapped against their country code.
+// The weekend of every country is taken from WeekendFor.
+func NewReleasePlannerFromCalendars(calendars map[string]*Calendar, movies []*Movie) (*ReleasePlanner, error) {
+	p := &ReleasePlanner{
+		Weights:   DefaultReleaseScoreWeights(),
+		calendars: make(map[string]*BusinessCalendar, len(calendars)),
+		movies:    movies,
+	}
+	for country, c := range calendars {
+		b, err := NewBusinessCalendar(c, WeekendFor(country)...)
+		if err != nil {
+			return nil, err
+		}
+		p.calendars[country] = b
+		p.countries = append(p.countries, country)
+	}
+	sort.Strings(p.countries)
+	return p, nil
+}
+
+// SetWeekend replaces the weekend days of a country, which default to WeekendFor.
+func (p *ReleasePlanner) SetWeekend(country string, weekend ...time.Weekday) error {
+	c, ok := p.calendars[country]
+	if !ok {
+		return fmt.Errorf("no holiday calendar for %s", country)
+	}
+	b, err := NewBusinessCalendar(c.Calendar, weekend...)
+	if err != nil {
+		return err
+	}
+	p.calendars[country] = b
+	return nil
+}
+
+// Suggest scores every candidate release date of the request and returns them ranked, best first.
+// Candidates with equal scores are ordered by date.
+func (p *ReleasePlanner) Suggest(req ReleasePlanRequest) []ReleaseSuggestion {
+	weekdays := req.Weekdays
+	if len(weekdays) == 0 {
+		weekdays = []time.Weekday{time.Friday}
+	}
+
+	var suggestions []ReleaseSuggestion
+	from, to := utcDay(req.From), utcDay(req.To)
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		for _, w := range weekdays {
+			if d.Weekday() == w {
+				suggestions = append(suggestions, p.score(d, req))
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Date.Before(suggestions[j].Date)
+	})
+
+	if req.Limit > 0 && len(suggestions) > req.Limit {
+		suggestions = suggestions[:req.Limit]
+	}
+	return suggestions
+}
+
+// score scores the release day d, at midnight UTC, in every country of the planner.
+func (p *ReleasePlanner) score(d time.Time, req ReleasePlanRequest) ReleaseSuggestion {
+	w := p.Weights
+	s := ReleaseSuggestion{
+		Date:         d,
+		Holidays:     map[string][]Holiday{},
+		LongWeekends: map[string]LongWeekend{},
+		Competing:    map[string][]*Movie{},
+	}
+
+	for _, country := range p.countries {
+		b := p.calendars[country]
+		local := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, b.Location)
+
+		holidays := b.HolidaysBetween(local.AddDate(0, 0, -w.HolidayWindow), local.AddDate(0, 0, w.HolidayWindow))
+		for _, h := range holidays {
+			date, _ := h.GetDate(b.Location)
+			distance := absDays(date, local)
+			s.Score += w.Holiday * (1 - float64(distance)/float64(w.HolidayWindow+1))
+			s.Reasons = append(s.Reasons, fmt.Sprintf("%s: %s on %s", country, h.Name, h.Date))
+		}
+		if len(holidays) > 0 {
+			s.Holidays[country] = holidays
+		}
+
+		if weekends := b.LongWeekendsBetween(local, local.AddDate(0, 0, 2)); len(weekends) > 0 {
+			lw := weekends[0]
+			s.Score += w.LongWeekend
+			s.LongWeekends[country] = lw
+			s.Reasons = append(s.Reasons, fmt.Sprintf("%s: long weekend from %s to %s", country,
+				lw.Start.Format(holidayDateLayout), lw.End.Format(holidayDateLayout)))
+		}
+
+		competing := p.competing(country, d, req)
+		if len(competing) > 0 {
+			s.Score += w.Competition * float64(len(competing))
+			s.Competing[country] = competing
+			names := make([]string, len(competing))
+			for i, m := range competing {
+				names[i] = m.Name
+			}
+			s.Reasons = append(s.Reasons, fmt.Sprintf("%s: %d competing releases: %s", country,
+				len(competing), strings.Join(names, ", ")))
+		}
+	}
+	return s
+}
+
+// competing returns the movies in the language of the request releasing in country around the day d.
+func (p *ReleasePlanner) competing(country string, d time.Time, req ReleasePlanRequest) []*Movie {
+	var competing []*Movie
+	for _, m := range p.movies {
+		if m.UUID != "" && m.UUID == req.ExcludeMovie {
+			continue
+		}
+		if req.Language != "" && !strings.EqualFold(strings.TrimSpace(m.Language), strings.TrimSpace(req.Language)) {
+			continue
+		}
+		released, err := time.Parse(holidayDateLayout, m.ReleaseDates[country])
+		if err != nil {
+			continue
+		}
+		if absDays(released, d) <= p.Weights.CompetitionWindow {
+			competing = append(competing, m)
+		}
+	}
+	return competing
+}
+
+// utcDay returns the midnight UTC of the calendar day of t.
+func utcDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// absDays returns the number of calendar days between a and b, ignoring the time of day.
+func absDays(a, b time.Time) int {
+	days := int(utcDay(a).Sub(utcDay(b)).Hours() / 24)
+	if days < 0 {
+		return -days
+	}
+	return days
+}
@@ -0,0 +1,92 @@
+package moviebuff
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleasePlanner_Suggest(t *testing.T) {
+	assert := assert.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter,
+		r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"name": "Holidays in India",
+			"timeZone": "Asia/Kolkata",
+			"holidays": [
+				{"id": "1", "name": "Republic Day", "date": "2024-01-26"},
+				{"id": "2", "name": "Makar Sankranti", "date": "2024-01-15"}
+			]
+		}`))
+	}))
+	defer ts.Close()
+
+	mb := New(Config{
+		HostURL:     ts.URL,
+		StaticToken: "staticToken",
+	})
+
+	movies := []*Movie{
+		{UUID: "m1", Name: "Big Hindi Movie", Language: "Hindi", ReleaseDates: map[string]string{"IN": "2024-01-12"}},
+		{UUID: "m2", Name: "Tamil Movie", Language: "Tamil", ReleaseDates: map[string]string{"IN": "2024-02-02"}},
+		{UUID: "self", Name: "Our Movie", Language: "Hindi", ReleaseDates: map[string]string{"IN": "2024-02-02"}},
+	}
+
+	planner, err := NewReleasePlanner(context.Background(), mb, []string{"IN"}, movies)
+	assert.NoError(err)
+
+	suggestions := planner.Suggest(ReleasePlanRequest{
+		From:         time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:           time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+		Language:     "hindi",
+		ExcludeMovie: "self",
+	})
+	assert.Len(suggestions, 6)
+
+	var dates []string
+	for _, s := range suggestions {
+		dates = append(dates, s.Date.Format("2006-01-02"))
+	}
+	// Republic Day and Makar Sankranti make long weekends, the 5th, 12th and 19th
+	// are a week or less from a Hindi release.
+	assert.Equal([]string{"2024-01-26", "2024-01-12", "2024-02-02", "2024-02-09", "2024-01-05", "2024-01-19"}, dates)
+
+	best := suggestions[0]
+	assert.Equal(1.0, best.Score)
+	assert.Equal("Republic Day", best.Holidays["IN"][0].Name)
+	assert.Equal(3, best.LongWeekends["IN"].Days)
+	assert.Len(best.Reasons, 2)
+
+	worst := suggestions[len(suggestions)-1]
+	assert.Len(worst.Competing["IN"], 1)
+	assert.Equal("Big Hindi Movie", worst.Competing["IN"][0].Name)
+
+	limited := planner.Suggest(ReleasePlanRequest{
+		From:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+		Weekdays: []time.Weekday{time.Thursday, time.Friday},
+		Limit:    3,
+	})
+	assert.Len(limited, 3)
+	assert.Equal("2024-01-25", limited[0].Date.Format("2006-01-02"))
+
+	// With a Sunday only weekend, Republic Day on Friday is not a long weekend anymore.
+	assert.NoError(planner.SetWeekend("IN", time.Sunday))
+	republicDay := planner.Suggest(ReleasePlanRequest{
+		From: time.Date(2024, 1, 26, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, 1, 26, 0, 0, 0, 0, time.UTC),
+	})
+	assert.Len(republicDay, 1)
+	assert.Empty(republicDay[0].LongWeekends)
+	assert.Error(planner.SetWeekend("US", time.Sunday))
+
+	// Changing the weights of a planner does not change the defaults.
+	planner.Weights.Holiday = 5
+	assert.Equal(-1.0, DefaultReleaseScoreWeights().Holiday)
+}
@@ -0,0 +1,154 @@
+package moviebuff
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// Release status of a movie in a country
+type ReleaseStatus string
+
+const (
+	RELEASE_STATUS_ANNOUNCED ReleaseStatus = "announced"
+	RELEASE_STATUS_UPCOMING  ReleaseStatus = "upcoming"
+	RELEASE_STATUS_RELEASED  ReleaseStatus = "released"
+	RELEASE_STATUS_CANCELLED ReleaseStatus = "cancelled"
+)
+
+// ParseReleaseStatus normalises a release status as returned by the API, like "Released" or " upcoming ".
+// Values that are not known are returned trimmed but otherwise unchanged.
+func ParseReleaseStatus(s string) ReleaseStatus {
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case string(RELEASE_STATUS_ANNOUNCED):
+		return RELEASE_STATUS_ANNOUNCED
+	case string(RELEASE_STATUS_UPCOMING):
+		return RELEASE_STATUS_UPCOMING
+	case string(RELEASE_STATUS_RELEASED):
+		return RELEASE_STATUS_RELEASED
+	case string(RELEASE_STATUS_CANCELLED), "canceled":
+		return RELEASE_STATUS_CANCELLED
+	}
+	return ReleaseStatus(s)
+}
+
+// ReleaseStatuses contains the release status of a movie mapped against the corresponding country code.
+type ReleaseStatuses struct {
+	// Release status in the United Arab Emirates.
+	//
+	// Deprecated: Use Get("AE") instead. AE is kept so that existing code keeps working:
+	// once set or changed, it takes precedence over Statuses["AE"].
+	AE string
+
+	// Release statuses mapped against their corresponding country code like "IN" : "released".
+	Statuses map[string]ReleaseStatus
+
+	// raw are the statuses as returned by the API, so that they are encoded back unchanged.
+	raw map[string]string
+}
+
+// UnmarshalJSON decodes the release statuses of every country.
+func (r *ReleaseStatuses) UnmarshalJSON(data []byte) error {
+	raw := map[string]string{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	statuses := make(map[string]ReleaseStatus, len(raw))
+	for country, status := range raw {
+		statuses[country] = ParseReleaseStatus(status)
+	}
+
+	*r = ReleaseStatuses{
+		AE:       raw["AE"],
+		Statuses: statuses,
+		raw:      raw,
+	}
+	return nil
+}
+
+// MarshalJSON encodes the release statuses as an object keyed by country code.
+// Statuses are encoded as returned by the API unless they were changed since.
+func (r ReleaseStatuses) MarshalJSON() ([]byte, error) {
+	statuses := make(map[string]string, len(r.Statuses)+1)
+	for country, status := range r.Statuses {
+		if country != "AE" {
+			statuses[country] = r.encode(country, status)
+		}
+	}
+	if status, ok := r.ae(); ok {
+		statuses["AE"] = status
+	}
+	return json.Marshal(statuses)
+}
+
+// encode returns the status of a country as returned by the API if it was not changed since.
+func (r *ReleaseStatuses) encode(country string, status ReleaseStatus) string {
+	if raw, ok := r.raw[country]; ok && ParseReleaseStatus(raw) == status {
+		return raw
+	}
+	return string(status)
+}
+
+// ae returns the encoded release status in the United Arab Emirates.
+// The deprecated AE field takes precedence over Statuses when it was set or changed since decoding,
+// so that code still writing AE keeps working.
+func (r *ReleaseStatuses) ae() (string, bool) {
+	if r.AE != r.raw["AE"] {
+		return r.AE, r.AE != ""
+	}
+	status, ok := r.Statuses["AE"]
+	if !ok {
+		return "", false
+	}
+	return r.encode("AE", status), true
+}
+
+// Get returns the release status for the given country code.
+// Returns empty value if the status is not available for the country
+func (r *ReleaseStatuses) Get(country string) ReleaseStatus {
+	if country == "AE" {
+		if status, ok := r.ae(); ok {
+			return ParseReleaseStatus(status)
+		}
+		return ""
+	}
+	return r.Statuses[country]
+}
+
+// Countries returns the sorted country codes of every country having a release status.
+func (r *ReleaseStatuses) Countries() []string {
+	countries := make([]string, 0, len(r.Statuses)+1)
+	for country := range r.Statuses {
+		if country != "AE" {
+			countries = append(countries, country)
+		}
+	}
+	if _, ok := r.ae(); ok {
+		countries = append(countries, "AE")
+	}
+	sort.Strings(countries)
+	return countries
+}
+
+// CountriesWithStatus returns the sorted country codes of every country in which the movie has the given status.
+func (r *ReleaseStatuses) CountriesWithStatus(status ReleaseStatus) []string {
+	var countries []string
+	for _, country := range r.Countries() {
+		if r.Get(country) == status {
+			countries = append(countries, country)
+		}
+	}
+	return countries
+}
+
+// ByStatus returns the sorted country codes grouped by their release status.
+func (r *ReleaseStatuses) ByStatus() map[ReleaseStatus][]string {
+	byStatus := map[ReleaseStatus][]string{}
+	for _, country := range r.Countries() {
+		status := r.Get(country)
+		byStatus[status] = append(byStatus[status], country)
+	}
+	return byStatus
+}
@@ -0,0 +1,70 @@
+package moviebuff
+
+import (
+	"time"
+)
+
+// ShowSlot describes how a show of a movie occupies a screen.
+type ShowSlot struct {
+	// Padding played before the movie starts, like ads and trailers.
+	Padding time.Duration
+
+	// Cleaning time needed after the show ends before the next show can begin.
+	Cleaning time.Duration
+
+	// Grid on which shows are allowed to start, like every 5 or 15 minutes.
+	// Grid is aligned to midnight of the start time's location. Zero means shows can start at any time.
+	Grid time.Duration
+}
+
+// Occupancy returns the total time a show of the given running time occupies the screen,
+// including padding and cleaning time.
+func (s ShowSlot) Occupancy(runningTime time.Duration) time.Duration {
+	if runningTime < 0 {
+		runningTime = 0
+	}
+	return s.Padding + runningTime + s.Cleaning
+}
+
+// MovieOccupancy returns the total time a show of the movie occupies the screen.
+func (s ShowSlot) MovieOccupancy(m *Movie) time.Duration {
+	return s.Occupancy(m.GetRunningTime())
+}
+
+// NextStartTimes returns count back to back start times for shows of the given running time.
+// The first show starts at the first grid point at or after from, and every following show
+// starts at the first grid point after the previous show has been cleaned.
+func (s ShowSlot) NextStartTimes(runningTime time.Duration, from time.Time, count int) []time.Time {
+	if count <= 0 {
+		return nil
+	}
+
+	occupancy := s.Occupancy(runningTime)
+	starts := make([]time.Time, 0, count)
+
+	next := s.alignToGrid(from)
+	for i := 0; i < count; i++ {
+		starts = append(starts, next)
+		next = s.alignToGrid(next.Add(occupancy))
+	}
+	return starts
+}
+
+// MovieStartTimes returns count back to back start times for shows of the movie. See NextStartTimes.
+func (s ShowSlot) MovieStartTimes(m *Movie, from time.Time, count int) []time.Time {
+	return s.NextStartTimes(m.GetRunningTime(), from, count)
+}
+
+// alignToGrid returns the first grid point at or after t.
+func (s ShowSlot) alignToGrid(t time.Time) time.Time {
+	if s.Grid <= 0 {
+		return t
+	}
+
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := t.Sub(midnight)
+	if rem := offset % s.Grid; rem != 0 {
+		offset += s.Grid - rem
+	}
+	return midnight.Add(offset)
+}
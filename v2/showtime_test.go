@@ -0,0 +1,74 @@
+package moviebuff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShowSlot_NextStartTimes(t *testing.T) {
+	ist := time.FixedZone("IST", 5*60*60+30*60)
+
+	var testCases = []struct {
+		desc              string
+		slot              ShowSlot
+		runningTime       time.Duration
+		from              time.Time
+		count             int
+		expectedOccupancy time.Duration
+		expectedStarts    []time.Time
+	}{
+		{
+			desc:              "no grid",
+			slot:              ShowSlot{Padding: 10 * time.Minute, Cleaning: 15 * time.Minute},
+			runningTime:       2*time.Hour + 14*time.Minute,
+			from:              time.Date(2024, 1, 1, 9, 7, 0, 0, time.UTC),
+			count:             2,
+			expectedOccupancy: 2*time.Hour + 39*time.Minute,
+			expectedStarts: []time.Time{
+				time.Date(2024, 1, 1, 9, 7, 0, 0, time.UTC),
+				time.Date(2024, 1, 1, 11, 46, 0, 0, time.UTC),
+			},
+		}, {
+			desc:              "15 minute grid",
+			slot:              ShowSlot{Padding: 10 * time.Minute, Cleaning: 15 * time.Minute, Grid: 15 * time.Minute},
+			runningTime:       2*time.Hour + 14*time.Minute,
+			from:              time.Date(2024, 1, 1, 9, 7, 0, 0, ist),
+			count:             3,
+			expectedOccupancy: 2*time.Hour + 39*time.Minute,
+			expectedStarts: []time.Time{
+				time.Date(2024, 1, 1, 9, 15, 0, 0, ist),
+				time.Date(2024, 1, 1, 12, 0, 0, 0, ist),
+				time.Date(2024, 1, 1, 14, 45, 0, 0, ist),
+			},
+		}, {
+			desc:              "start already on grid",
+			slot:              ShowSlot{Grid: 30 * time.Minute},
+			runningTime:       90 * time.Minute,
+			from:              time.Date(2024, 1, 1, 22, 30, 0, 0, time.UTC),
+			count:             2,
+			expectedOccupancy: 90 * time.Minute,
+			expectedStarts: []time.Time{
+				time.Date(2024, 1, 1, 22, 30, 0, 0, time.UTC),
+				time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+		}, {
+			desc:              "zero count",
+			slot:              ShowSlot{Grid: 30 * time.Minute},
+			runningTime:       90 * time.Minute,
+			from:              time.Date(2024, 1, 1, 22, 30, 0, 0, time.UTC),
+			count:             0,
+			expectedOccupancy: 90 * time.Minute,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			assert.Equal(testCase.expectedOccupancy, testCase.slot.Occupancy(testCase.runningTime))
+			assert.Equal(testCase.expectedStarts, testCase.slot.NextStartTimes(testCase.runningTime, testCase.from, testCase.count))
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package moviebuff
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ThirdPartyIndex is a reverse index of the third-party identifiers of movies,
+// like IMDb or TMDb ids, to the Moviebuff movies carrying them.
+// It is safe for concurrent use.
+type ThirdPartyIndex struct {
+	mu sync.RWMutex
+
+	// ids maps a source UUID and a normalised id to the UUIDs of the movies carrying it.
+	ids map[string]map[string][]string
+
+	// names maps a normalised source name to the source UUIDs having that name.
+	names map[string][]string
+
+	// sourceNames maps a source UUID to its name as returned by the API.
+	sourceNames map[string]string
+
+	// nameCounts maps a source UUID and a name as returned by the API to the number of movies indexed with it.
+	nameCounts map[string]map[string]int
+
+	// movies maps a movie UUID to its reference and the ids it was indexed with.
+	movies map[string]indexedMovie
+}
+
+type indexedMovie struct {
+	resource Resource
+	ids      []ThirdPartyIdentifier
+}
+
+// ThirdPartyConflict is a third-party identifier carried by more than one movie.
+type ThirdPartyConflict struct {
+	SourceUUID string
+	SourceName string
+	ID         string
+	Movies     []Resource
+}
+
+// NewThirdPartyIndex returns an index of the given movies.
+func NewThirdPartyIndex(movies ...*Movie) *ThirdPartyIndex {
+	idx := &ThirdPartyIndex{
+		ids:         map[string]map[string][]string{},
+		names:       map[string][]string{},
+		sourceNames: map[string]string{},
+		nameCounts:  map[string]map[string]int{},
+		movies:      map[string]indexedMovie{},
+	}
+	for _, m := range movies {
+		idx.Add(m)
+	}
+	return idx
+}
+
+// normalizeThirdPartyID trims and lower cases an id, as third-party ids like "tt1234567" are case insensitive.
+func normalizeThirdPartyID(id string) string {
+	return strings.ToLower(strings.TrimSpace(id))
+}
+
+// Add indexes the third-party identifiers of a movie.
+// Adding a movie again replaces the identifiers it was indexed with before.
+func (idx *ThirdPartyIndex) Add(m *Movie) {
+	if m == nil || m.UUID == "" {
+		return
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(m.UUID)
+
+	idx.movies[m.UUID] = indexedMovie{
+		resource: Resource{
+			Name:         m.Name,
+			URL:          m.URL,
+			UUID:         m.UUID,
+			Type:         m.Type,
+			Poster:       m.Poster,
+			APIPath:      m.APIPath,
+			MoviebuffURL: m.MoviebuffURL,
+		},
+		ids: copyThirdPartyIdentifiers(m.ThirdPartyIdentifiers),
+	}
+
+	for _, tpi := range m.ThirdPartyIdentifiers {
+		source := tpi.Source.UUID
+		if source == "" {
+			continue
+		}
+
+		if tpi.Source.Name != "" {
+			if idx.nameCounts[source] == nil {
+				idx.nameCounts[source] = map[string]int{}
+			}
+			idx.nameCounts[source][tpi.Source.Name]++
+			idx.sourceNames[source] = tpi.Source.Name
+			name := normalizeName(tpi.Source.Name)
+			if !containsString(idx.names[name], source) {
+				idx.names[name] = append(idx.names[name], source)
+			}
+		}
+
+		if idx.ids[source] == nil {
+			idx.ids[source] = map[string][]string{}
+		}
+		for _, id := range tpi.IDs {
+			id = normalizeThirdPartyID(id)
+			if id == "" || containsString(idx.ids[source][id], m.UUID) {
+				continue
+			}
+			idx.ids[source][id] = append(idx.ids[source][id], m.UUID)
+		}
+	}
+}
+
+// Remove drops a movie from the index.
+func (idx *ThirdPartyIndex) Remove(movieUUID string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(movieUUID)
+}
+
+func (idx *ThirdPartyIndex) remove(movieUUID string) {
+	indexed, ok := idx.movies[movieUUID]
+	if !ok {
+		return
+	}
+
+	for _, tpi := range indexed.ids {
+		source := tpi.Source.UUID
+		if source == "" {
+			continue
+		}
+
+		if ids := idx.ids[source]; ids != nil {
+			for _, id := range tpi.IDs {
+				id = normalizeThirdPartyID(id)
+				ids[id] = removeString(ids[id], movieUUID)
+				if len(ids[id]) == 0 {
+					delete(ids, id)
+				}
+			}
+			if len(ids) == 0 {
+				delete(idx.ids, source)
+			}
+		}
+
+		if tpi.Source.Name != "" && idx.nameCounts[source] != nil {
+			idx.nameCounts[source][tpi.Source.Name]--
+			if idx.nameCounts[source][tpi.Source.Name] <= 0 {
+				delete(idx.nameCounts[source], tpi.Source.Name)
+				idx.removeSourceName(source, tpi.Source.Name)
+			}
+		}
+	}
+	delete(idx.movies, movieUUID)
+}
+
+// removeSourceName drops a name of a source no indexed movie carries anymore.
+func (idx *ThirdPartyIndex) removeSourceName(source, name string) {
+	counts := idx.nameCounts[source]
+
+	normalized := normalizeName(name)
+	stillNamed := false
+	for other := range counts {
+		stillNamed = stillNamed || normalizeName(other) == normalized
+	}
+	if !stillNamed {
+		idx.names[normalized] = removeString(idx.names[normalized], source)
+		if len(idx.names[normalized]) == 0 {
+			delete(idx.names, normalized)
+		}
+	}
+
+	if idx.sourceNames[source] != name {
+		return
+	}
+	delete(idx.sourceNames, source)
+	if len(counts) == 0 {
+		delete(idx.nameCounts, source)
+		return
+	}
+	remaining := make([]string, 0, len(counts))
+	for other := range counts {
+		remaining = append(remaining, other)
+	}
+	sort.Strings(remaining)
+	idx.sourceNames[source] = remaining[0]
+}
+
+// Len returns the number of movies in the index.
+func (idx *ThirdPartyIndex) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return len(idx.movies)
+}
+
+// Lookup returns the movies carrying the id of the source with the given UUID.
+// Returns nil if no movie carries the id
+func (idx *ThirdPartyIndex) Lookup(sourceUUID, id string) []Resource {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return idx.lookup(sourceUUID, normalizeThirdPartyID(id))
+}
+
+// LookupByName returns the movies carrying the id of the source with the given name like "IMDb".
+// Source names are compared ignoring case and punctuation.
+// Returns nil if no movie carries the id
+func (idx *ThirdPartyIndex) LookupByName(sourceName, id string) []Resource {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var movies []Resource
+	for _, source := range idx.names[normalizeName(sourceName)] {
+		for _, m := range idx.lookup(source, normalizeThirdPartyID(id)) {
+			if !containsResource(movies, m.UUID) {
+				movies = append(movies, m)
+			}
+		}
+	}
+	return movies
+}
+
+func (idx *ThirdPartyIndex) lookup(sourceUUID, id string) []Resource {
+	var movies []Resource
+	for _, uuid := range idx.ids[sourceUUID][id] {
+		movies = append(movies, idx.movies[uuid].resource)
+	}
+	return movies
+}
+
+// Conflicts returns the third-party identifiers carried by more than one movie,
+// sorted by source name and id.
+func (idx *ThirdPartyIndex) Conflicts() []ThirdPartyConflict {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var conflicts []ThirdPartyConflict
+	for source, ids := range idx.ids {
+		for id, uuids := range ids {
+			if len(uuids) < 2 {
+				continue
+			}
+			conflicts = append(conflicts, ThirdPartyConflict{
+				SourceUUID: source,
+				SourceName: idx.sourceNames[source],
+				ID:         id,
+				Movies:     idx.lookup(source, id),
+			})
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].SourceName != conflicts[j].SourceName {
+			return conflicts[i].SourceName < conflicts[j].SourceName
+		}
+		if conflicts[i].SourceUUID != conflicts[j].SourceUUID {
+			return conflicts[i].SourceUUID < conflicts[j].SourceUUID
+		}
+		return conflicts[i].ID < conflicts[j].ID
+	})
+	return conflicts
+}
+
+// copyThirdPartyIdentifiers returns a copy of identifiers, so that changes made by the caller
+// after indexing do not change what is removed from the index.
+func copyThirdPartyIdentifiers(identifiers []ThirdPartyIdentifier) []ThirdPartyIdentifier {
+	copied := make([]ThirdPartyIdentifier, len(identifiers))
+	for i, tpi := range identifiers {
+		copied[i] = tpi
+		copied[i].IDs = append([]string(nil), tpi.IDs...)
+	}
+	return copied
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(list []string, s string) []string {
+	kept := list[:0]
+	for _, v := range list {
+		if v != s {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+func containsResource(list []Resource, uuid string) bool {
+	for _, r := range list {
+		if r.UUID == uuid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package moviebuff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func thirdPartyMovie(uuid, name string, imdb ...string) *Movie {
+	m := &Movie{UUID: uuid, Name: name, Type: "movie"}
+	tpi := ThirdPartyIdentifier{IDs: imdb}
+	tpi.Source.UUID = "imdb-uuid"
+	tpi.Source.Name = "IMDb"
+	m.ThirdPartyIdentifiers = append(m.ThirdPartyIdentifiers, tpi)
+	return m
+}
+
+func TestThirdPartyIndex(t *testing.T) {
+	assert := assert.New(t)
+
+	idx := NewThirdPartyIndex(
+		thirdPartyMovie("m1", "Padmaavat", "tt5935704"),
+		thirdPartyMovie("m2", "Padmaavat (Tamil)", "TT5935704 "),
+		thirdPartyMovie("m3", "Baahubali", "tt2631186"),
+	)
+	assert.Equal(3, idx.Len())
+
+	movies := idx.Lookup("imdb-uuid", "tt2631186")
+	assert.Len(movies, 1)
+	assert.Equal("Baahubali", movies[0].Name)
+
+	movies = idx.LookupByName("imdb", "tt5935704")
+	assert.Len(movies, 2)
+	assert.Nil(idx.LookupByName("tmdb", "tt5935704"))
+	assert.Nil(idx.Lookup("imdb-uuid", "tt0000000"))
+
+	conflicts := idx.Conflicts()
+	assert.Len(conflicts, 1)
+	assert.Equal("IMDb", conflicts[0].SourceName)
+	assert.Equal("tt5935704", conflicts[0].ID)
+	assert.Equal("m1", conflicts[0].Movies[0].UUID)
+	assert.Equal("m2", conflicts[0].Movies[1].UUID)
+
+	idx.Add(thirdPartyMovie("m2", "Padmaavat (Tamil)", "tt9999999"))
+	assert.Empty(idx.Conflicts())
+	assert.Len(idx.Lookup("imdb-uuid", "tt9999999"), 1)
+
+	idx.Remove("m2")
+	assert.Nil(idx.Lookup("imdb-uuid", "tt9999999"))
+	assert.Equal(2, idx.Len())
+
+	assert.Equal([]string{"tt2631186"}, thirdPartyMovie("m3", "Baahubali", "tt2631186").GetThirdPartyIDsBySourceName("imdb"))
+}
+
+func TestThirdPartyIndex_RemoveBySourceName(t *testing.T) {
+	assert := assert.New(t)
+
+	tmdbMovie := func(uuid, sourceName, id string) *Movie {
+		m := &Movie{UUID: uuid, Type: "movie"}
+		tpi := ThirdPartyIdentifier{IDs: []string{id}}
+		tpi.Source.UUID = "tmdb-uuid"
+		tpi.Source.Name = sourceName
+		m.ThirdPartyIdentifiers = append(m.ThirdPartyIdentifiers, tpi)
+		return m
+	}
+
+	idx := NewThirdPartyIndex(tmdbMovie("m1", "TMDb", "1"), tmdbMovie("m2", "TMDb", "2"))
+	assert.Len(idx.LookupByName("tmdb", "1"), 1)
+
+	idx.Remove("m1")
+	assert.Nil(idx.LookupByName("tmdb", "1"))
+	assert.Len(idx.LookupByName("tmdb", "2"), 1, "the source keeps the name of the other movie")
+
+	idx.Add(tmdbMovie("m2", "The Movie Database", "2"))
+	assert.Nil(idx.LookupByName("tmdb", "2"), "the source is not found by its old name")
+	movies := idx.LookupByName("the movie database", "2")
+	if assert.Len(movies, 1) {
+		assert.Equal("m2", movies[0].UUID)
+	}
+
+	idx.Remove("m2")
+	assert.Nil(idx.LookupByName("the movie database", "2"))
+	assert.Empty(idx.names)
+	assert.Empty(idx.sourceNames)
+	assert.Empty(idx.nameCounts)
+	assert.Empty(idx.ids)
+}
+
+func TestThirdPartyIndex_AddChangedMovie(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &Movie{UUID: "m1", Type: "movie"}
+	tpi := ThirdPartyIdentifier{IDs: []string{"tt1"}}
+	tpi.Source.UUID = "imdb-uuid"
+	tpi.Source.Name = "IMDb"
+	m.ThirdPartyIdentifiers = append(m.ThirdPartyIdentifiers, tpi)
+
+	idx := NewThirdPartyIndex(m)
+
+	// The movie is changed in place after being indexed, then indexed again.
+	m.ThirdPartyIdentifiers[0].Source.Name = "Internet Movie Database"
+	m.ThirdPartyIdentifiers[0].IDs[0] = "tt2"
+	idx.Add(m)
+
+	assert.Nil(idx.Lookup("imdb-uuid", "tt1"), "stale ids are removed")
+	assert.Len(idx.Lookup("imdb-uuid", "tt2"), 1)
+	assert.Nil(idx.LookupByName("imdb", "tt2"))
+	assert.Len(idx.LookupByName("internet movie database", "tt2"), 1)
+
+	idx.Remove("m1")
+	assert.Empty(idx.ids)
+	assert.Empty(idx.names)
+	assert.Empty(idx.nameCounts)
+}
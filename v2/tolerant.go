@@ -0,0 +1,112 @@
+package moviebuff
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// TolerantString is a string decoded from whatever the API returns in its place.
+//
+// Strings are used as is, null decodes to an empty string, numbers and booleans to their literal text,
+// arrays to their non-empty elements joined by ", " and objects to the first non-empty of their
+// "url", "name", "value" or "text" fields. It is encoded as a plain JSON string.
+type TolerantString string
+
+// Fields looked up, in order, when a TolerantString is decoded from an object.
+var tolerantObjectFields = []string{"url", "name", "value", "text"}
+
+// String returns s as a string.
+func (s TolerantString) String() string {
+	return string(s)
+}
+
+// UnmarshalJSON decodes a string, null, number, boolean, array or object into s.
+func (s *TolerantString) UnmarshalJSON(data []byte) error {
+	list, err := decodeTolerant(data)
+	if err != nil {
+		return err
+	}
+	*s = TolerantString(strings.Join(list, ", "))
+	return nil
+}
+
+// TolerantStringList is a list of strings decoded from whatever the API returns in its place.
+//
+// A string decodes to a list of one element, null to an empty list and an array to its elements,
+// each decoded like a TolerantString. It is encoded as a JSON array of strings.
+type TolerantStringList []string
+
+// UnmarshalJSON decodes a string, null, number, boolean, array or object into l.
+func (l *TolerantStringList) UnmarshalJSON(data []byte) error {
+	list, err := decodeTolerant(data)
+	if err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// decodeTolerant decodes any JSON value into the non-empty strings it holds.
+func decodeTolerant(data []byte) ([]string, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+
+	switch data[0] {
+	case '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+		if s == "" {
+			return nil, nil
+		}
+		return []string{s}, nil
+
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+		var list []string
+		for _, item := range items {
+			var s TolerantString
+			if err := s.UnmarshalJSON(item); err != nil {
+				return nil, err
+			}
+			if s != "" {
+				list = append(list, string(s))
+			}
+		}
+		return list, nil
+
+	case '{':
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, err
+		}
+		for _, name := range tolerantObjectFields {
+			value, ok := fields[name]
+			if !ok {
+				continue
+			}
+			var s TolerantString
+			if err := s.UnmarshalJSON(value); err != nil {
+				return nil, err
+			}
+			if s != "" {
+				return []string{string(s)}, nil
+			}
+		}
+		return nil, nil
+
+	default:
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		return []string{string(data)}, nil
+	}
+}
@@ -0,0 +1,108 @@
+package moviebuff
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTolerantString_UnmarshalJSON(t *testing.T) {
+	var testCases = []struct {
+		desc         string
+		data         string
+		expected     TolerantString
+		expectedList TolerantStringList
+		expectErr    bool
+	}{
+		{
+			desc:         "string",
+			data:         `"Guest"`,
+			expected:     "Guest",
+			expectedList: TolerantStringList{"Guest"},
+		}, {
+			desc: "empty string",
+			data: `""`,
+		}, {
+			desc: "null",
+			data: `null`,
+		}, {
+			desc:         "number",
+			data:         `42`,
+			expected:     "42",
+			expectedList: TolerantStringList{"42"},
+		}, {
+			desc:         "array",
+			data:         `["Ram", null, "", "Shyam"]`,
+			expected:     "Ram, Shyam",
+			expectedList: TolerantStringList{"Ram", "Shyam"},
+		}, {
+			desc:         "object with url",
+			data:         `{"url": "https://example.com/poster.jpg", "name": "Poster"}`,
+			expected:     "https://example.com/poster.jpg",
+			expectedList: TolerantStringList{"https://example.com/poster.jpg"},
+		}, {
+			desc:         "object with name",
+			data:         `{"url": null, "name": "Ram"}`,
+			expected:     "Ram",
+			expectedList: TolerantStringList{"Ram"},
+		}, {
+			desc: "object without known fields",
+			data: `{"foo": "bar"}`,
+		}, {
+			desc:      "invalid json",
+			data:      `{"foo"`,
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var s TolerantString
+			err := s.UnmarshalJSON([]byte(testCase.data))
+			var l TolerantStringList
+			listErr := l.UnmarshalJSON([]byte(testCase.data))
+			if testCase.expectErr {
+				assert.Error(err)
+				assert.Error(listErr)
+				return
+			}
+
+			assert.NoError(err)
+			assert.NoError(listErr)
+			assert.Equal(testCase.expected, s)
+			assert.Equal(testCase.expectedList, l)
+		})
+	}
+}
+
+func TestEntity_TolerantCredits(t *testing.T) {
+	assert := assert.New(t)
+
+	e := new(Entity)
+	err := json.Unmarshal([]byte(`{
+		"credits": [{
+			"department": "Production",
+			"roles": [
+				{"name": "A", "poster": "a.jpg", "character": null},
+				{"name": "B", "poster": {"url": "b.jpg"}, "character": ["X", "Y"]},
+				{"name": "C", "poster": null, "character": "Z"}
+			]
+		}]
+	}`), e)
+	assert.NoError(err)
+
+	roles := e.Credits[0].Roles
+	assert.Equal(TolerantString("a.jpg"), roles[0].Poster)
+	assert.Equal(TolerantString(""), roles[0].Character)
+	assert.Equal(TolerantString("b.jpg"), roles[1].Poster)
+	assert.Equal(TolerantString("X, Y"), roles[1].Character)
+	assert.Equal(TolerantString(""), roles[2].Poster)
+	assert.Equal("Z", roles[2].Character.String())
+
+	content, err := json.Marshal(roles[1].Character)
+	assert.NoError(err)
+	assert.Equal(`"X, Y"`, string(content))
+}